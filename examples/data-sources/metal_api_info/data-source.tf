@@ -0,0 +1,5 @@
+data "metal_api_info" "api" {}
+
+output "api_version" {
+  value = data.metal_api_info.api.version
+}
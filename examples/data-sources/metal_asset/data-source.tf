@@ -1,5 +1,11 @@
 data "metal_assets" "assets" {}
 
+data "metal_assets" "munich" {
+  region                 = "muc"
+  machine_type_ids       = ["n1-medium-x86", "c1-medium-x86"]
+  kubernetes_min_version = "1.29"
+}
+
 output "assets" {
   value = data.metal_assets.assets
 }
@@ -0,0 +1,13 @@
+data "metal_audit_traces" "cluster_changes" {
+  project     = metal_cluster.cluster.project
+  method      = "/api.v1.ClusterService/Update"
+  result_code = 0
+  from        = timeadd(plantimestamp(), "-168h")
+  limit       = 20
+}
+
+output "cluster_changes" {
+  value = [
+    for trace in data.metal_audit_traces.cluster_changes.traces : "${trace.timestamp} ${trace.user}"
+  ]
+}
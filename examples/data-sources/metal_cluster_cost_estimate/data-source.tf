@@ -0,0 +1,18 @@
+data "metal_cluster_cost_estimate" "planned" {
+  workers = [
+    {
+      name         = "default"
+      machine_type = "n1-medium-x86"
+      min_size     = 1
+      max_size     = 3
+    }
+  ]
+}
+
+data "metal_cluster_cost_estimate" "existing" {
+  cluster_id = metal_cluster.cluster.id
+}
+
+output "monthly_costs" {
+  value = "${data.metal_cluster_cost_estimate.existing.min_monthly} - ${data.metal_cluster_cost_estimate.existing.max_monthly}"
+}
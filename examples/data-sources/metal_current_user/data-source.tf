@@ -0,0 +1,8 @@
+data "metal_current_user" "me" {
+}
+
+resource "metal_public_ip" "egress" {
+  name        = "egress"
+  description = "applied by ${data.metal_current_user.me.id}"
+  type        = "static"
+}
@@ -0,0 +1,16 @@
+data "metal_kubernetes_version" "latest_1_30" {
+  constraint = "~> 1.30.0"
+}
+
+resource "metal_cluster" "cluster" {
+  name       = "cluster"
+  kubernetes = data.metal_kubernetes_version.latest_1_30.version
+  workers = [
+    {
+      name         = "group-0"
+      machine_type = "n1-medium-x86"
+      min_size     = 1
+      max_size     = 3
+    }
+  ]
+}
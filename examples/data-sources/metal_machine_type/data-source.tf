@@ -0,0 +1,18 @@
+data "metal_machine_type" "worker" {
+  min_cpus   = 8
+  min_memory = 64 * 1024 * 1024 * 1024
+  prefer     = "smallest"
+}
+
+resource "metal_cluster" "cluster" {
+  name       = "cluster"
+  kubernetes = "1.29.5"
+  workers = [
+    {
+      name         = "group-0"
+      machine_type = data.metal_machine_type.worker.id
+      min_size     = 1
+      max_size     = 3
+    }
+  ]
+}
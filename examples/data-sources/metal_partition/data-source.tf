@@ -0,0 +1,17 @@
+data "metal_partition" "mu4" {
+  id = "eqx-mu4"
+}
+
+resource "metal_cluster" "cluster" {
+  name       = "cluster"
+  kubernetes = "1.29.5"
+  partition  = data.metal_partition.mu4.id
+  workers = [
+    {
+      name         = "group-0"
+      machine_type = "n1-medium-x86"
+      min_size     = 1
+      max_size     = 3
+    }
+  ]
+}
@@ -0,0 +1,3 @@
+data "metal_project" "team" {
+  name = "team-a"
+}
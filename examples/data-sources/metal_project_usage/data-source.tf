@@ -0,0 +1,9 @@
+data "metal_project_usage" "last_month" {
+  project = metal_project.team_a.id
+  from    = "2024-05-01T00:00:00Z"
+  to      = "2024-06-01T00:00:00Z"
+}
+
+output "worker_hours" {
+  value = data.metal_project_usage.last_month.totals.worker_hours_by_machine_type
+}
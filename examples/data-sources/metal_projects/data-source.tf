@@ -0,0 +1,4 @@
+data "metal_projects" "all" {
+}
+
+# access with `data.metal_projects.all.items`
@@ -0,0 +1,7 @@
+data "metal_public_ip" "ingress" {
+  name = "ingress"
+}
+
+output "ingress_address" {
+  value = data.metal_public_ip.ingress.ip
+}
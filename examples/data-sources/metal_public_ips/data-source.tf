@@ -2,3 +2,8 @@ data "metal_public_ips" "all_ips" {
 }
 
 # access with `metal_public_ips.all_ips.items`
+
+data "metal_public_ips" "static_prod_ips" {
+  type = "static"
+  tags = ["prod"]
+}
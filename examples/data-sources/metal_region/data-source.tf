@@ -0,0 +1,7 @@
+data "metal_region" "munich" {
+  name = "Munich"
+}
+
+output "default_partition" {
+  value = data.metal_region.munich.defaults.partition
+}
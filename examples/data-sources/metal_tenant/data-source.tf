@@ -0,0 +1,7 @@
+# the tenant owning the provider project
+data "metal_tenant" "current" {
+}
+
+output "billing_email" {
+  value = data.metal_tenant.current.email
+}
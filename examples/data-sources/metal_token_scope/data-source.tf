@@ -0,0 +1,28 @@
+variable "project" {
+  type = string
+}
+
+data "metal_token_scope" "current" {
+}
+
+resource "metal_cluster" "cluster" {
+  name       = "cluster"
+  project    = var.project
+  kubernetes = "1.28.10"
+  partition  = "eqx-mu4"
+  workers = [
+    {
+      name         = "default"
+      machine_type = "n1-medium-x86"
+      min_size     = 1
+      max_size     = 3
+    }
+  ]
+
+  lifecycle {
+    precondition {
+      condition     = contains(["owner", "editor"], lookup(data.metal_token_scope.current.project_roles, var.project, ""))
+      error_message = "The api token needs to be owner or editor of the project."
+    }
+  }
+}
@@ -0,0 +1,10 @@
+resource "metal_project" "team" {
+  name        = "team-a"
+  description = "Workloads of team A"
+}
+
+resource "metal_public_ip" "team_ip" {
+  name    = "team-a-egress"
+  project = metal_project.team.id
+  type    = "static"
+}
@@ -0,0 +1,9 @@
+resource "metal_project_invite" "new_colleague" {
+  project = metal_project.team.id
+  role    = "editor"
+}
+
+output "join_link" {
+  value     = metal_project_invite.new_colleague.join_link
+  sensitive = true
+}
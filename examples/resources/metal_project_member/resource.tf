@@ -0,0 +1,5 @@
+resource "metal_project_member" "octocat" {
+  project   = metal_project.team.id
+  member_id = "octocat@github"
+  role      = "viewer"
+}
@@ -0,0 +1,5 @@
+# import by UUID, address or name in the provider project
+terraform import metal_public_ip.my_ip 212.34.83.12
+
+# import from another project, given by UUID or name
+terraform import metal_public_ip.my_ip my-project/212.34.83.12
@@ -3,4 +3,13 @@ resource "metal_public_ip" "my_ip" {
   description = "Some description"
   type        = "ephemeral" # either ephemeral or static
   tags        = ["test"]
+  labels = {
+    team = "platform"
+  }
+}
+
+resource "metal_public_ip" "ingress_v6" {
+  name           = "ingress-v6"
+  type           = "static"
+  address_family = "ipv6"
 }
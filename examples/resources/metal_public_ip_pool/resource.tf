@@ -0,0 +1,11 @@
+resource "metal_public_ip_pool" "edge" {
+  name_prefix = "edge"
+  size        = 10
+  type        = "static"
+  tags        = ["edge"]
+}
+
+# the addresses keep their position when the pool is resized
+output "edge_ips" {
+  value = metal_public_ip_pool.edge.ips
+}
@@ -0,0 +1,4 @@
+resource "metal_tenant_member" "octocat" {
+  member_id = "octocat@github"
+  role      = "guest"
+}
@@ -0,0 +1,22 @@
+resource "time_rotating" "monthly" {
+  rotation_days = 30
+}
+
+resource "metal_token" "ci" {
+  description = "CI of cluster my-cluster"
+  expires_in  = "1440h"
+
+  permissions = [
+    {
+      subject = metal_cluster.my_cluster.project
+      methods = [
+        "/api.v1.ClusterService/Get",
+        "/api.v1.ClusterService/GetCredentials",
+      ]
+    },
+  ]
+
+  rotation_triggers = {
+    rotated_at = time_rotating.monthly.id
+  }
+}
@@ -0,0 +1,35 @@
+package apiinfo_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/metal-stack-cloud/terraform-provider-metal/internal/provider"
+)
+
+var (
+	testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+		"metal": providerserver.NewProtocol6WithError(provider.New("test")()),
+	}
+)
+
+func TestAccApiInfoDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExampleDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.metal_api_info.api", "version"),
+					resource.TestCheckResourceAttr("data.metal_api_info.api", "compatible", "true"),
+				),
+			},
+		},
+	})
+}
+
+const testAccExampleDataSourceConfig = `
+data "metal_api_info" "api" {}
+`
@@ -0,0 +1,73 @@
+package apiinfo
+
+import (
+	"errors"
+	"testing"
+
+	apiv1 "github.com/metal-stack-cloud/api/go/api/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckCompatibility(t *testing.T) {
+	tests := []struct {
+		name          string
+		serverVersion string
+		wantErr       string
+		wantUnknown   bool
+	}{
+		{
+			name:          "minimum version",
+			serverVersion: "v0.16.0",
+		},
+		{
+			name:          "newer patch version",
+			serverVersion: "v0.16.8",
+		},
+		{
+			name:          "newer minor version",
+			serverVersion: "v0.17.0",
+		},
+		{
+			name:          "older minor version",
+			serverVersion: "v0.15.9",
+			wantErr:       "the metalstack.cloud API is at version v0.15.9, but this provider requires at least v0.16.0",
+		},
+		{
+			name:          "pre-release of minimum version",
+			serverVersion: "v0.16.0-rc.1",
+		},
+		{
+			name:          "development build",
+			serverVersion: "devel",
+			wantErr:       `the version of the metalstack.cloud API is unknown: "devel" cannot be compared with the required version v0.16.0`,
+			wantUnknown:   true,
+		},
+		{
+			name:          "empty version",
+			serverVersion: "",
+			wantErr:       `the version of the metalstack.cloud API is unknown: "" cannot be compared with the required version v0.16.0`,
+			wantUnknown:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckCompatibility(tt.serverVersion)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantUnknown, errors.Is(err, ErrUnknownVersion))
+		})
+	}
+}
+
+func TestUnhealthyServices(t *testing.T) {
+	ipam := &apiv1.HealthStatus{Name: apiv1.Service_SERVICE_IPAM, Status: apiv1.ServiceStatus_SERVICE_STATUS_UNHEALTHY}
+	machines := &apiv1.HealthStatus{Name: apiv1.Service_SERVICE_MACHINES, Status: apiv1.ServiceStatus_SERVICE_STATUS_HEALTHY}
+	other := &apiv1.HealthStatus{Name: apiv1.Service_SERVICE_UNSPECIFIED, Status: apiv1.ServiceStatus_SERVICE_STATUS_DEGRADED}
+	health := &apiv1.Health{Services: []*apiv1.HealthStatus{ipam, machines, other}}
+
+	assert.Equal(t, []*apiv1.HealthStatus{ipam, other}, UnhealthyServices(health))
+	assert.Equal(t, []*apiv1.HealthStatus{ipam}, UnhealthyServices(health, UsedServices...))
+}
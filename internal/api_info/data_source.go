@@ -0,0 +1,75 @@
+package apiinfo
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	apiv1 "github.com/metal-stack-cloud/api/go/api/v1"
+	"github.com/metal-stack-cloud/terraform-provider-metal/internal/session"
+)
+
+var (
+	_ datasource.DataSource              = &ApiInfoDataSource{}
+	_ datasource.DataSourceWithConfigure = &ApiInfoDataSource{}
+)
+
+func NewApiInfoDataSource() datasource.DataSource {
+	return &ApiInfoDataSource{}
+}
+
+type ApiInfoDataSource struct {
+	session *session.Session
+}
+
+// Metadata implements datasource.DataSource.
+func (*ApiInfoDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_info"
+}
+
+// Schema implements datasource.DataSource.
+func (*ApiInfoDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Shows the version and health of the metalstack.cloud API.",
+		MarkdownDescription: "Shows the version and health of the metalstack.cloud API. \n" +
+			"Useful to assert in `precondition` blocks that the API is healthy before changing anything.",
+		Attributes: apiInfoDataSourceAttributes(),
+	}
+}
+
+// Configure implements datasource.DataSourceWithConfigure.
+func (a *ApiInfoDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	session, ok := req.ProviderData.(*session.Session)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *session.Session, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	a.session = session
+}
+
+// Read implements datasource.DataSource.
+func (a *ApiInfoDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	versionResp, err := a.session.Client.Apiv1().Version().Get(ctx, connect.NewRequest(&apiv1.VersionServiceGetRequest{}))
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to get API version", err.Error())
+		return
+	}
+	healthResp, err := a.session.Client.Apiv1().Health().Get(ctx, connect.NewRequest(&apiv1.HealthServiceGetRequest{}))
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to get API health", err.Error())
+		return
+	}
+
+	data := apiInfoFromApi(versionResp.Msg.GetVersion(), healthResp.Msg.GetHealth(), ClientVersion())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,87 @@
+package apiinfo
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	apiv1 "github.com/metal-stack-cloud/api/go/api/v1"
+)
+
+const apiModulePath = "github.com/metal-stack-cloud/api"
+
+// ClientVersion returns the version of the metal-stack-cloud/api client this provider was built with.
+// It is empty if the build carries no module information, e.g. in tests.
+func ClientVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	for _, dep := range info.Deps {
+		if dep.Path != apiModulePath {
+			continue
+		}
+		if dep.Replace != nil {
+			return dep.Replace.Version
+		}
+		return dep.Version
+	}
+	return ""
+}
+
+// MinimumApiVersion is the oldest version of the API server this provider works with.
+// Raise it whenever the provider starts to depend on API features added in a newer release.
+const MinimumApiVersion = "v0.16.0"
+
+// ErrUnknownVersion is returned by CheckCompatibility if the server version cannot be compared, e.g. for development builds.
+var ErrUnknownVersion = errors.New("the version of the metalstack.cloud API is unknown")
+
+// CheckCompatibility returns an error if the server is older than MinimumApiVersion.
+// If the server version is not a semantic version, an error wrapping ErrUnknownVersion is returned.
+func CheckCompatibility(serverVersion string) error {
+	server, err := version.NewVersion(serverVersion)
+	if err != nil {
+		return fmt.Errorf("%w: %q cannot be compared with the required version %s", ErrUnknownVersion, serverVersion, MinimumApiVersion)
+	}
+
+	minimum := version.Must(version.NewVersion(MinimumApiVersion))
+	if server.Core().LessThan(minimum) {
+		return fmt.Errorf("the metalstack.cloud API is at version %s, but this provider requires at least %s", serverVersion, MinimumApiVersion)
+	}
+	return nil
+}
+
+// UsedServices are the services backing the resources and data sources of this provider.
+var UsedServices = []apiv1.Service{
+	apiv1.Service_SERVICE_IPAM,
+	apiv1.Service_SERVICE_RETHINK,
+	apiv1.Service_SERVICE_MASTERDATA,
+	apiv1.Service_SERVICE_MACHINES,
+}
+
+// UnhealthyServices returns the services of the health report which are not healthy, limited to the given services if any.
+func UnhealthyServices(health *apiv1.Health, services ...apiv1.Service) []*apiv1.HealthStatus {
+	var unhealthy []*apiv1.HealthStatus
+	for _, service := range health.GetServices() {
+		if len(services) > 0 && !slices.Contains(services, service.Name) {
+			continue
+		}
+		if service.Status != apiv1.ServiceStatus_SERVICE_STATUS_HEALTHY {
+			unhealthy = append(unhealthy, service)
+		}
+	}
+	return unhealthy
+}
+
+// ServiceName turns SERVICE_IPAM into ipam.
+func ServiceName(service apiv1.Service) string {
+	return strings.ToLower(strings.TrimPrefix(service.String(), "SERVICE_"))
+}
+
+// ServiceStatusName turns SERVICE_STATUS_HEALTHY into healthy.
+func ServiceStatusName(status apiv1.ServiceStatus) string {
+	return strings.ToLower(strings.TrimPrefix(status.String(), "SERVICE_STATUS_"))
+}
@@ -0,0 +1,54 @@
+package apiinfo
+
+import (
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	apiv1 "github.com/metal-stack-cloud/api/go/api/v1"
+)
+
+type apiInfoModel struct {
+	Version       types.String         `tfsdk:"version"`
+	Revision      types.String         `tfsdk:"revision"`
+	GitSha1       types.String         `tfsdk:"git_sha1"`
+	BuildDate     types.String         `tfsdk:"build_date"`
+	ClientVersion types.String         `tfsdk:"client_version"`
+	Compatible    types.Bool           `tfsdk:"compatible"`
+	Healthy       types.Bool           `tfsdk:"healthy"`
+	Services      []serviceHealthModel `tfsdk:"services"`
+}
+
+type serviceHealthModel struct {
+	Name    types.String `tfsdk:"name"`
+	Status  types.String `tfsdk:"status"`
+	Message types.String `tfsdk:"message"`
+}
+
+func apiInfoFromApi(v *apiv1.Version, h *apiv1.Health, clientVersion string) apiInfoModel {
+	services := make([]serviceHealthModel, 0, len(h.GetServices()))
+	for _, s := range h.GetServices() {
+		services = append(services, serviceHealthModel{
+			Name:    types.StringValue(ServiceName(s.Name)),
+			Status:  types.StringValue(ServiceStatusName(s.Status)),
+			Message: types.StringValue(s.Message),
+		})
+	}
+
+	compatible := types.BoolValue(true)
+	if err := CheckCompatibility(v.Version); errors.Is(err, ErrUnknownVersion) {
+		compatible = types.BoolNull()
+	} else if err != nil {
+		compatible = types.BoolValue(false)
+	}
+
+	return apiInfoModel{
+		Version:       types.StringValue(v.Version),
+		Revision:      types.StringValue(v.Revision),
+		GitSha1:       types.StringValue(v.GitSha1),
+		BuildDate:     types.StringValue(v.BuildDate),
+		ClientVersion: types.StringValue(clientVersion),
+		Compatible:    compatible,
+		Healthy:       types.BoolValue(len(UnhealthyServices(h)) == 0),
+		Services:      services,
+	}
+}
@@ -0,0 +1,58 @@
+package apiinfo
+
+import (
+	dataschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+)
+
+func apiInfoDataSourceAttributes() map[string]dataschema.Attribute {
+	return map[string]dataschema.Attribute{
+		"version": dataschema.StringAttribute{
+			Computed:    true,
+			Description: "The version of the API server.",
+		},
+		"revision": dataschema.StringAttribute{
+			Computed:    true,
+			Description: "The revision of the API server.",
+		},
+		"git_sha1": dataschema.StringAttribute{
+			Computed:    true,
+			Description: "The git commit the API server was built from.",
+		},
+		"build_date": dataschema.StringAttribute{
+			Computed:    true,
+			Description: "The date the API server was built.",
+		},
+		"client_version": dataschema.StringAttribute{
+			Computed:    true,
+			Description: "The version of the API client this provider was built with.",
+		},
+		"compatible": dataschema.BoolAttribute{
+			Computed:    true,
+			Description: "Indicates if the API server is at least at the minimum version this provider requires. Null if the server version cannot be compared, e.g. for development builds.",
+		},
+		"healthy": dataschema.BoolAttribute{
+			Computed:    true,
+			Description: "Indicates if all services of the API report to be healthy.",
+		},
+		"services": dataschema.ListNestedAttribute{
+			Computed:    true,
+			Description: "The health of the individual services behind the API.",
+			NestedObject: dataschema.NestedAttributeObject{
+				Attributes: map[string]dataschema.Attribute{
+					"name": dataschema.StringAttribute{
+						Computed:    true,
+						Description: "The name of the service.",
+					},
+					"status": dataschema.StringAttribute{
+						Computed:    true,
+						Description: "The status of the service, one of `healthy`, `degraded`, `unhealthy` or `unspecified`.",
+					},
+					"message": dataschema.StringAttribute{
+						Computed:    true,
+						Description: "Explains the status of the service.",
+					},
+				},
+			},
+		},
+	}
+}
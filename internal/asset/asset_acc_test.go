@@ -168,5 +168,6 @@ func TestAccAssetDataSource(t *testing.T) {
 
 const testAccExampleDataSourceConfig = `
 data "metal_assets" "assets" {
+  region = "muc"
 }
 `
@@ -0,0 +1,267 @@
+package asset
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	apiv1 "github.com/metal-stack-cloud/api/go/api/v1"
+	assert "github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func Test_selectMachineType(t *testing.T) {
+	const gib = 1024 * 1024 * 1024
+	small := &apiv1.MachineType{Id: "n1-medium-x86", Cpus: 4, Memory: 32 * gib, Storage: 960 * gib}
+	large := &apiv1.MachineType{Id: "c1-large-x86", Cpus: 16, Memory: 64 * gib, Storage: 960 * gib}
+	xlarge := &apiv1.MachineType{Id: "c1-xlarge-x86", Cpus: 32, Memory: 256 * gib, Storage: 3840 * gib}
+	assets := []*apiv1.Asset{
+		{
+			Region:       &apiv1.Region{Id: "muc"},
+			MachineTypes: map[string]*apiv1.MachineType{small.Id: small, large.Id: large},
+		},
+		{
+			Region:       &apiv1.Region{Id: "fra"},
+			MachineTypes: map[string]*apiv1.MachineType{large.Id: large, xlarge.Id: xlarge},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		region     string
+		minCpus    uint64
+		minMemory  uint64
+		minStorage uint64
+		prefer     string
+		want       *apiv1.MachineType
+		wantErr    string
+	}{
+		{
+			name: "smallest without requirements",
+			want: small,
+		},
+		{
+			name:   "largest in all regions",
+			prefer: preferLargest,
+			want:   xlarge,
+		},
+		{
+			name:   "largest in a region",
+			region: "muc",
+			prefer: preferLargest,
+			want:   large,
+		},
+		{
+			name:      "smallest with enough memory",
+			minMemory: 48 * gib,
+			prefer:    preferSmallest,
+			want:      large,
+		},
+		{
+			name:       "requirements match nothing in the region",
+			region:     "muc",
+			minStorage: 2000 * gib,
+			wantErr:    "no machine type has at least 0 cpus, 0 bytes of memory and 2147483648000 bytes of storage",
+		},
+		{
+			name:    "unknown region",
+			region:  "ber",
+			wantErr: `region "ber" not found`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := selectMachineType(assets, tt.region, tt.minCpus, tt.minMemory, tt.minStorage, tt.prefer)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_matchKubernetesVersions(t *testing.T) {
+	now := time.Unix(1717932877, 0)
+	assets := []*apiv1.Asset{
+		{
+			Region: &apiv1.Region{Id: "muc", Defaults: &apiv1.AssetDefaults{KubernetesVersion: "1.30.1"}},
+			Kubernetes: []*apiv1.Kubernetes{
+				{Version: "1.30.1"},
+				{Version: "1.28.10", Expiration: &timestamppb.Timestamp{Seconds: int64(1707382100)}},
+				{Version: "1.29.5"},
+				{Version: "1.30.2"},
+			},
+		},
+		{
+			Region: &apiv1.Region{Id: "fra", Defaults: &apiv1.AssetDefaults{KubernetesVersion: "1.30.2"}},
+			Kubernetes: []*apiv1.Kubernetes{
+				{Version: "1.30.2"},
+				{Version: "1.31.0"},
+				{Version: "1.28.10"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		region        string
+		constraint    string
+		want          []string
+		wantIsDefault bool
+		wantErr       string
+	}{
+		{
+			name:       "newest patch release of a minor version in a region",
+			region:     "muc",
+			constraint: "~> 1.30.0",
+			want:       []string{"1.30.2", "1.30.1"},
+		},
+		{
+			name:          "range in all regions",
+			constraint:    ">= 1.29, < 1.31",
+			want:          []string{"1.30.2", "1.30.1", "1.29.5"},
+			wantIsDefault: true,
+		},
+		{
+			name:       "expired versions are skipped",
+			region:     "muc",
+			constraint: "< 1.29",
+			wantErr:    `no supported kubernetes version matches "< 1.29"`,
+		},
+		{
+			name:       "version expired in one region is supported in another",
+			constraint: "< 1.29",
+			want:       []string{"1.28.10"},
+		},
+		{
+			name:       "invalid constraint",
+			constraint: "latest",
+			wantErr:    "invalid version constraint: malformed constraint: latest",
+		},
+		{
+			name:       "unknown region",
+			region:     "ber",
+			constraint: ">= 1.0",
+			wantErr:    `region "ber" not found`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, isDefault, err := matchKubernetesVersions(assets, tt.region, tt.constraint, now)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantIsDefault, isDefault)
+		})
+	}
+}
+
+func Test_findRegionAndPartition(t *testing.T) {
+	assets := []*apiv1.Asset{
+		{
+			Region: &apiv1.Region{
+				Id: "muc", Name: "Munich", Active: true,
+				Partitions: map[string]*apiv1.Partition{
+					"eqx-mu4": {Id: "eqx-mu4", Name: "Equinix MU4", Active: true},
+					"eqx-mu5": {Id: "eqx-mu5", Name: "Equinix MU5"},
+				},
+			},
+		},
+		{
+			Region: &apiv1.Region{
+				Id: "fra", Name: "Frankfurt",
+				Partitions: map[string]*apiv1.Partition{
+					"eqx-fr2": {Id: "eqx-fr2", Name: "Equinix FR2", Active: true},
+				},
+			},
+		},
+	}
+
+	region, err := findRegion(assets, "", "Munich")
+	assert.NoError(t, err)
+	assert.Equal(t, "muc", region.Id)
+	_, err = findRegion(assets, "fra", "")
+	assert.EqualError(t, err, `region "fra" is not active`)
+	_, err = findRegion(assets, "ber", "")
+	assert.EqualError(t, err, `region "ber" not found`)
+
+	partition, regionId, err := findPartition(assets, "", "", "Equinix MU4")
+	assert.NoError(t, err)
+	assert.Equal(t, "eqx-mu4", partition.Id)
+	assert.Equal(t, "muc", regionId)
+	_, _, err = findPartition(assets, "", "eqx-mu5", "")
+	assert.EqualError(t, err, `partition "eqx-mu5" is not active`)
+	_, _, err = findPartition(assets, "muc", "eqx-fr2", "")
+	assert.EqualError(t, err, `partition "eqx-fr2" not found`)
+	_, _, err = findPartition(assets, "", "eqx-fr2", "")
+	assert.EqualError(t, err, `region "fra" of partition "eqx-fr2" is not active`)
+
+	mapped := regionFromApi(assets[0].Region)
+	assert.Equal(t, "eqx-mu4", mapped.Partitions[0].Id.ValueString())
+	assert.Equal(t, "eqx-mu5", mapped.Partitions[1].Id.ValueString())
+	assert.Nil(t, mapped.Defaults)
+}
+
+func Test_filterAssets(t *testing.T) {
+	small := &apiv1.MachineType{Id: "n1-medium-x86", Memory: 32}
+	other := &apiv1.MachineType{Id: "n2-medium-x86", Memory: 32}
+	large := &apiv1.MachineType{Id: "c1-large-x86", Memory: 64}
+	assets := []*apiv1.Asset{
+		{
+			Region: &apiv1.Region{
+				Id: "muc",
+				Partitions: map[string]*apiv1.Partition{
+					"eqx-mu4": {Id: "eqx-mu4"},
+					"eqx-mu5": {Id: "eqx-mu5"},
+				},
+			},
+			MachineTypes: map[string]*apiv1.MachineType{large.Id: large, other.Id: other, small.Id: small},
+			Kubernetes:   []*apiv1.Kubernetes{{Version: "1.30.1"}, {Version: "1.9.0"}, {Version: "1.29.5"}},
+		},
+		{
+			Region:       &apiv1.Region{Id: "fra", Partitions: map[string]*apiv1.Partition{"eqx-fr2": {Id: "eqx-fr2"}}},
+			MachineTypes: map[string]*apiv1.MachineType{large.Id: large},
+			Kubernetes:   []*apiv1.Kubernetes{{Version: "1.30.1"}},
+		},
+	}
+
+	all, err := filterAssets(assets, AssetListDataSourceModel{})
+	assert.NoError(t, err)
+	assert.Len(t, all, 2)
+	assert.Equal(t, "fra", all[0].Region.Id)
+
+	mapped := assetResponseMapping(all[1])
+	var machineTypeIds, versions []string
+	for _, m := range mapped.MachineTypes {
+		machineTypeIds = append(machineTypeIds, m.Id.ValueString())
+	}
+	for _, k := range mapped.Kubernetes {
+		versions = append(versions, k.Version.ValueString())
+	}
+	assert.Equal(t, []string{"n1-medium-x86", "n2-medium-x86", "c1-large-x86"}, machineTypeIds)
+	assert.Equal(t, []string{"1.9.0", "1.29.5", "1.30.1"}, versions)
+
+	filtered, err := filterAssets(assets, AssetListDataSourceModel{
+		Partition:            basetypes.NewStringValue("eqx-mu5"),
+		MachineTypeIds:       []basetypes.StringValue{basetypes.NewStringValue("c1-large-x86")},
+		KubernetesMinVersion: basetypes.NewStringValue("1.29"),
+	})
+	assert.NoError(t, err)
+	assert.Len(t, filtered, 1)
+	assert.Equal(t, map[string]*apiv1.Partition{"eqx-mu5": {Id: "eqx-mu5"}}, filtered[0].Region.Partitions)
+	assert.Equal(t, map[string]*apiv1.MachineType{large.Id: large}, filtered[0].MachineTypes)
+	assert.Equal(t, []*apiv1.Kubernetes{{Version: "1.30.1"}, {Version: "1.29.5"}}, filtered[0].Kubernetes)
+	assert.Len(t, assets[0].Region.Partitions, 2, "filtering must not modify the api response")
+
+	first := assetsContentId([]assetModel{assetResponseMapping(filtered[0])})
+	second := assetsContentId([]assetModel{assetResponseMapping(filtered[0])})
+	assert.Equal(t, first, second)
+
+	_, err = filterAssets(assets, AssetListDataSourceModel{KubernetesMinVersion: basetypes.NewStringValue("latest")})
+	assert.ErrorContains(t, err, "kubernetes_min_version is no valid version")
+}
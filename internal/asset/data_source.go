@@ -7,6 +7,7 @@ import (
 	connect "connectrpc.com/connect"
 	datasource "github.com/hashicorp/terraform-plugin-framework/datasource"
 	schema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	types "github.com/hashicorp/terraform-plugin-framework/types"
 	apiv1 "github.com/metal-stack-cloud/api/go/api/v1"
 	session "github.com/metal-stack-cloud/terraform-provider-metal/internal/session"
 )
@@ -29,9 +30,31 @@ func (*AssetDataSource) Metadata(ctx context.Context, request datasource.Metadat
 
 func (*AssetDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, response *datasource.SchemaResponse) {
 	response.Schema = schema.Schema{
-		Description:         "Shows the available assets like Kubernetes versions and regions.",
-		MarkdownDescription: "Shows the available assets like Kubernetes versions and regions.",
+		Description: "Shows the available assets like Kubernetes versions and regions.",
+		MarkdownDescription: "Shows the available assets like Kubernetes versions and regions. " +
+			"Items are sorted by region id, machine types by memory and Kubernetes versions ascending.",
 		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "A hash of all listed regions, partitions, machine types and Kubernetes versions.",
+			},
+			"region": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list the assets of the region with this id.",
+			},
+			"partition": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list the region containing the partition with this id, and only this partition of it.",
+			},
+			"machine_type_ids": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Only list the machine types with these ids.",
+			},
+			"kubernetes_min_version": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list Kubernetes versions greater than or equal to this version.",
+			},
 			"items": schema.ListNestedAttribute{
 				Computed:    true,
 				Description: "A list of assets.",
@@ -74,12 +97,20 @@ func (a *AssetDataSource) Read(ctx context.Context, request datasource.ReadReque
 
 	if err != nil {
 		response.Diagnostics.AddError("Failed to get assets list", err.Error())
+		return
+	}
+
+	assets, err := filterAssets(assetResp.Msg.Assets, data)
+	if err != nil {
+		response.Diagnostics.AddError("Invalid asset filter", err.Error())
+		return
 	}
 
-	data.Items = make([]assetModel, 0, len(assetResp.Msg.Assets))
-	for _, asset := range assetResp.Msg.Assets {
+	data.Items = make([]assetModel, 0, len(assets))
+	for _, asset := range assets {
 		data.Items = append(data.Items, assetResponseMapping(asset))
 	}
+	data.ContentId = types.StringValue(assetsContentId(data.Items))
 
 	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
 }
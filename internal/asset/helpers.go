@@ -1,42 +1,63 @@
 package asset
 
 import (
+	"crypto/sha1"
+	"fmt"
 	"sort"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/go-version"
 	types "github.com/hashicorp/terraform-plugin-framework/types"
 	apiv1 "github.com/metal-stack-cloud/api/go/api/v1"
 )
 
-func assetResponseMapping(a *apiv1.Asset) assetModel {
+func regionFromApi(r *apiv1.Region) *region {
 	var partitions []*partition
-	for _, p := range a.Region.Partitions {
-		partition := partition{
-			Id:          types.StringValue(p.Id),
-			Name:        types.StringValue(p.Name),
-			Address:     types.StringValue(p.Address),
-			Active:      types.BoolValue(p.Active),
-			Description: types.StringValue(p.Description),
-		}
-		partitions = append(partitions, &partition)
-	}
-
-	defaults := assetDefault{
-		MachineType:       types.StringValue(a.Region.Defaults.MachineType),
-		KubernetesVersion: types.StringValue(a.Region.Defaults.KubernetesVersion),
-		WorkerMin:         types.Int64Value(int64(a.Region.Defaults.WorkerMin)),
-		WorkerMax:         types.Int64Value(int64(a.Region.Defaults.WorkerMax)),
-		Partition:         types.StringValue(a.Region.Defaults.Partition),
-	}
-
-	region := region{
-		Id:          types.StringValue(a.Region.Id),
-		Name:        types.StringValue(a.Region.Name),
-		Address:     types.StringValue(a.Region.Address),
-		Active:      types.BoolValue(a.Region.Active),
+	for _, p := range r.Partitions {
+		partitions = append(partitions, partitionFromApi(p))
+	}
+	// partitions are a map in the api, sort them to keep the state stable
+	sort.Slice(partitions, func(i, j int) bool {
+		return partitions[i].Id.ValueString() < partitions[j].Id.ValueString()
+	})
+
+	return &region{
+		Id:          types.StringValue(r.Id),
+		Name:        types.StringValue(r.Name),
+		Address:     types.StringValue(r.Address),
+		Active:      types.BoolValue(r.Active),
 		Partitions:  partitions,
-		Defaults:    &defaults,
-		Description: types.StringValue(a.Region.Description),
+		Defaults:    assetDefaultFromApi(r.Defaults),
+		Description: types.StringValue(r.Description),
+	}
+}
+
+func partitionFromApi(p *apiv1.Partition) *partition {
+	return &partition{
+		Id:          types.StringValue(p.Id),
+		Name:        types.StringValue(p.Name),
+		Address:     types.StringValue(p.Address),
+		Active:      types.BoolValue(p.Active),
+		Description: types.StringValue(p.Description),
+	}
+}
+
+func assetDefaultFromApi(d *apiv1.AssetDefaults) *assetDefault {
+	if d == nil {
+		return nil
 	}
+	return &assetDefault{
+		MachineType:       types.StringValue(d.MachineType),
+		KubernetesVersion: types.StringValue(d.KubernetesVersion),
+		WorkerMin:         types.Int64Value(int64(d.WorkerMin)),
+		WorkerMax:         types.Int64Value(int64(d.WorkerMax)),
+		Partition:         types.StringValue(d.Partition),
+	}
+}
+
+func assetResponseMapping(a *apiv1.Asset) assetModel {
+	region := regionFromApi(a.Region)
 
 	var machineTypes []*machineType
 	for _, m := range a.MachineTypes {
@@ -52,7 +73,10 @@ func assetResponseMapping(a *apiv1.Asset) assetModel {
 		machineTypes = append(machineTypes, &machineType)
 	}
 	sort.Slice(machineTypes, func(i, j int) bool {
-		return int(machineTypes[i].Memory.ValueInt64()) < int(machineTypes[j].Memory.ValueInt64())
+		if machineTypes[i].Memory.ValueInt64() != machineTypes[j].Memory.ValueInt64() {
+			return machineTypes[i].Memory.ValueInt64() < machineTypes[j].Memory.ValueInt64()
+		}
+		return machineTypes[i].Id.ValueString() < machineTypes[j].Id.ValueString()
 	})
 
 	var kubernetesVersions []*kubernetesVersion
@@ -62,10 +86,263 @@ func assetResponseMapping(a *apiv1.Asset) assetModel {
 		}
 		kubernetesVersions = append(kubernetesVersions, &kv)
 	}
+	sort.Slice(kubernetesVersions, func(i, j int) bool {
+		return kubernetesVersionLess(kubernetesVersions[i].Version.ValueString(), kubernetesVersions[j].Version.ValueString())
+	})
 
 	return assetModel{
-		Region:       &region,
+		Region:       region,
 		MachineTypes: machineTypes,
 		Kubernetes:   kubernetesVersions,
 	}
 }
+
+const (
+	preferSmallest = "smallest"
+	preferLargest  = "largest"
+)
+
+// selectMachineType returns the smallest or largest machine type offered in the given region, or in any region if empty,
+// which satisfies all minimum requirements. Machine types are ordered by memory, cpus and storage.
+func selectMachineType(assets []*apiv1.Asset, region string, minCpus, minMemory, minStorage uint64, prefer string) (*apiv1.MachineType, error) {
+	var candidates []*apiv1.MachineType
+	seen := map[string]bool{}
+	regionFound := false
+	for _, a := range assets {
+		if region != "" && a.Region.Id != region {
+			continue
+		}
+		regionFound = true
+		for _, m := range a.MachineTypes {
+			if seen[m.Id] {
+				continue
+			}
+			seen[m.Id] = true
+			if uint64(m.Cpus) < minCpus || m.Memory < minMemory || m.Storage < minStorage {
+				continue
+			}
+			candidates = append(candidates, m)
+		}
+	}
+	if !regionFound {
+		return nil, fmt.Errorf("region %q not found", region)
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no machine type has at least %d cpus, %d bytes of memory and %d bytes of storage", minCpus, minMemory, minStorage)
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.Memory != b.Memory {
+			return a.Memory < b.Memory
+		}
+		if a.Cpus != b.Cpus {
+			return a.Cpus < b.Cpus
+		}
+		if a.Storage != b.Storage {
+			return a.Storage < b.Storage
+		}
+		return a.Id < b.Id
+	})
+	if prefer == preferLargest {
+		return candidates[len(candidates)-1], nil
+	}
+	return candidates[0], nil
+}
+
+// matchKubernetesVersions returns all versions offered in the given region, or in any region if empty, which match the
+// constraint and are not expired at now, newest first. isDefault reports whether the newest match is a region default.
+func matchKubernetesVersions(assets []*apiv1.Asset, region, constraint string, now time.Time) (matches []string, isDefault bool, err error) {
+	constraints, err := version.NewConstraint(constraint)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid version constraint: %w", err)
+	}
+
+	var (
+		versions    []*version.Version
+		defaults    = map[string]bool{}
+		seen        = map[string]bool{}
+		regionFound bool
+	)
+	for _, a := range assets {
+		if region != "" && a.Region.Id != region {
+			continue
+		}
+		regionFound = true
+		if a.Region.Defaults != nil {
+			defaults[a.Region.Defaults.KubernetesVersion] = true
+		}
+		for _, k := range a.Kubernetes {
+			if seen[k.Version] {
+				continue
+			}
+			// a version expired in one region may still be supported in another
+			if k.Expiration != nil && k.Expiration.AsTime().Before(now) {
+				continue
+			}
+			v, err := version.NewVersion(k.Version)
+			if err != nil || !constraints.Check(v) {
+				continue
+			}
+			seen[k.Version] = true
+			versions = append(versions, v)
+		}
+	}
+	if !regionFound {
+		return nil, false, fmt.Errorf("region %q not found", region)
+	}
+	if len(versions) == 0 {
+		return nil, false, fmt.Errorf("no supported kubernetes version matches %q", constraint)
+	}
+
+	sort.Sort(sort.Reverse(version.Collection(versions)))
+	for _, v := range versions {
+		matches = append(matches, v.Original())
+	}
+	return matches, defaults[matches[0]], nil
+}
+
+// kubernetesVersionLess orders semantic versions by precedence and all other versions lexically after them.
+func kubernetesVersionLess(a, b string) bool {
+	va, errA := version.NewVersion(a)
+	vb, errB := version.NewVersion(b)
+	switch {
+	case errA == nil && errB == nil:
+		return va.LessThan(vb)
+	case errA == nil:
+		return true
+	case errB == nil:
+		return false
+	default:
+		return a < b
+	}
+}
+
+// filterAssets returns copies of the assets matching the configured filters, sorted by region id.
+// The partition filter drops all other partitions, the machine type and kubernetes filters drop non-matching entries.
+func filterAssets(assets []*apiv1.Asset, data AssetListDataSourceModel) ([]*apiv1.Asset, error) {
+	var minVersion *version.Version
+	if data.KubernetesMinVersion.ValueString() != "" {
+		v, err := version.NewVersion(data.KubernetesMinVersion.ValueString())
+		if err != nil {
+			return nil, fmt.Errorf("kubernetes_min_version is no valid version: %w", err)
+		}
+		minVersion = v
+	}
+	machineTypeIds := map[string]bool{}
+	for _, id := range data.MachineTypeIds {
+		machineTypeIds[id.ValueString()] = true
+	}
+
+	var result []*apiv1.Asset
+	for _, a := range assets {
+		if data.Region.ValueString() != "" && a.Region.Id != data.Region.ValueString() {
+			continue
+		}
+
+		// protobuf messages must not be copied by value, the filtered asset is built from the fields of the original
+		region := &apiv1.Region{
+			Id:          a.Region.Id,
+			Name:        a.Region.Name,
+			Address:     a.Region.Address,
+			Active:      a.Region.Active,
+			Partitions:  a.Region.Partitions,
+			Defaults:    a.Region.Defaults,
+			Description: a.Region.Description,
+		}
+		if partition := data.Partition.ValueString(); partition != "" {
+			p, ok := a.Region.Partitions[partition]
+			if !ok {
+				continue
+			}
+			region.Partitions = map[string]*apiv1.Partition{partition: p}
+		}
+
+		filtered := &apiv1.Asset{
+			Region:       region,
+			MachineTypes: a.MachineTypes,
+			Kubernetes:   a.Kubernetes,
+			Defaults:     a.Defaults,
+		}
+		if len(machineTypeIds) > 0 {
+			filtered.MachineTypes = map[string]*apiv1.MachineType{}
+			for id, m := range a.MachineTypes {
+				if machineTypeIds[m.Id] {
+					filtered.MachineTypes[id] = m
+				}
+			}
+		}
+		if minVersion != nil {
+			filtered.Kubernetes = nil
+			for _, k := range a.Kubernetes {
+				v, err := version.NewVersion(k.Version)
+				if err != nil || v.LessThan(minVersion) {
+					continue
+				}
+				filtered.Kubernetes = append(filtered.Kubernetes, k)
+			}
+		}
+		result = append(result, filtered)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Region.Id < result[j].Region.Id
+	})
+	return result, nil
+}
+
+// assetsContentId hashes the ids of all regions, partitions, machine types and kubernetes versions.
+func assetsContentId(items []assetModel) string {
+	var ids []string
+	for _, item := range items {
+		ids = append(ids, item.Region.Id.ValueString())
+		for _, p := range item.Region.Partitions {
+			ids = append(ids, p.Id.ValueString())
+		}
+		for _, m := range item.MachineTypes {
+			ids = append(ids, m.Id.ValueString())
+		}
+		for _, k := range item.Kubernetes {
+			ids = append(ids, k.Version.ValueString())
+		}
+	}
+	return fmt.Sprintf("%x", sha1.Sum([]byte(strings.Join(ids, ","))))
+}
+
+// findRegion returns the region with the given id or name and fails if it is not active.
+func findRegion(assets []*apiv1.Asset, id, name string) (*apiv1.Region, error) {
+	for _, a := range assets {
+		r := a.Region
+		if (id != "" && r.Id != id) || (name != "" && r.Name != name) {
+			continue
+		}
+		if !r.Active {
+			return nil, fmt.Errorf("region %q is not active", r.Id)
+		}
+		return r, nil
+	}
+	return nil, fmt.Errorf("region %q not found", id+name)
+}
+
+// findPartition returns the partition with the given id or name, in the given region if not empty, together with
+// the id of its region. It fails if the partition or its region is not active.
+func findPartition(assets []*apiv1.Asset, region, id, name string) (*apiv1.Partition, string, error) {
+	for _, a := range assets {
+		if region != "" && a.Region.Id != region {
+			continue
+		}
+		for _, p := range a.Region.Partitions {
+			if (id != "" && p.Id != id) || (name != "" && p.Name != name) {
+				continue
+			}
+			if !p.Active {
+				return nil, "", fmt.Errorf("partition %q is not active", p.Id)
+			}
+			if !a.Region.Active {
+				return nil, "", fmt.Errorf("region %q of partition %q is not active", a.Region.Id, p.Id)
+			}
+			return p, a.Region.Id, nil
+		}
+	}
+	return nil, "", fmt.Errorf("partition %q not found", id+name)
+}
@@ -0,0 +1,107 @@
+package asset
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	connect "connectrpc.com/connect"
+	datasource "github.com/hashicorp/terraform-plugin-framework/datasource"
+	schema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	types "github.com/hashicorp/terraform-plugin-framework/types"
+	apiv1 "github.com/metal-stack-cloud/api/go/api/v1"
+	session "github.com/metal-stack-cloud/terraform-provider-metal/internal/session"
+)
+
+var (
+	_ datasource.DataSource              = &KubernetesVersionDataSource{}
+	_ datasource.DataSourceWithConfigure = &KubernetesVersionDataSource{}
+)
+
+func NewKubernetesVersionDataSource() datasource.DataSource {
+	return &KubernetesVersionDataSource{}
+}
+
+type KubernetesVersionDataSource struct {
+	session *session.Session
+}
+
+func (*KubernetesVersionDataSource) Metadata(ctx context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_kubernetes_version"
+}
+
+func (*KubernetesVersionDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Description: "Selects the newest supported Kubernetes version matching a version constraint.",
+		MarkdownDescription: "Selects the newest supported Kubernetes version matching a version constraint, e.g. to follow the latest patch release of a minor version. " +
+			"Expired versions are never selected. Required permissions: `Asset List`.",
+		Attributes: map[string]schema.Attribute{
+			"constraint": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The version constraint in Terraform syntax, e.g. `~> 1.30.0` or `>= 1.29, < 1.31`.",
+			},
+			"region": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only select versions offered in this region. Defaults to all regions.",
+			},
+			"version": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The newest matching version.",
+			},
+			"versions": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "All matching versions, newest first.",
+			},
+			"is_default": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether `version` is the default Kubernetes version of the region, or of any region if `region` is not set.",
+			},
+		},
+	}
+}
+
+func (k *KubernetesVersionDataSource) Configure(ctx context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
+	if request.ProviderData == nil {
+		return
+	}
+
+	client, ok := request.ProviderData.(*session.Session)
+	if !ok {
+		response.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *session.Session, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+		)
+		return
+	}
+
+	k.session = client
+}
+
+func (k *KubernetesVersionDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	var data kubernetesVersionDataSourceModel
+	response.Diagnostics.Append(request.Config.Get(ctx, &data)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	assetResp, err := k.session.Client.Apiv1().Asset().List(ctx, connect.NewRequest(&apiv1.AssetServiceListRequest{}))
+	if err != nil {
+		response.Diagnostics.AddError("Failed to get assets list", err.Error())
+		return
+	}
+
+	matches, isDefault, err := matchKubernetesVersions(assetResp.Msg.Assets, data.Region.ValueString(), data.Constraint.ValueString(), time.Now())
+	if err != nil {
+		response.Diagnostics.AddError("No matching kubernetes version", err.Error())
+		return
+	}
+
+	data.Version = types.StringValue(matches[0])
+	data.Versions = make([]types.String, 0, len(matches))
+	for _, v := range matches {
+		data.Versions = append(data.Versions, types.StringValue(v))
+	}
+	data.IsDefault = types.BoolValue(isDefault)
+	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
+}
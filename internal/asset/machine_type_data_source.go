@@ -0,0 +1,148 @@
+package asset
+
+import (
+	"context"
+	"fmt"
+
+	connect "connectrpc.com/connect"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	datasource "github.com/hashicorp/terraform-plugin-framework/datasource"
+	schema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	types "github.com/hashicorp/terraform-plugin-framework/types"
+	apiv1 "github.com/metal-stack-cloud/api/go/api/v1"
+	session "github.com/metal-stack-cloud/terraform-provider-metal/internal/session"
+)
+
+var (
+	_ datasource.DataSource              = &MachineTypeDataSource{}
+	_ datasource.DataSourceWithConfigure = &MachineTypeDataSource{}
+)
+
+func NewMachineTypeDataSource() datasource.DataSource {
+	return &MachineTypeDataSource{}
+}
+
+type MachineTypeDataSource struct {
+	session *session.Session
+}
+
+func (*MachineTypeDataSource) Metadata(ctx context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_machine_type"
+}
+
+func (*MachineTypeDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	minimum := func(description string) schema.Int64Attribute {
+		return schema.Int64Attribute{
+			Optional:            true,
+			MarkdownDescription: description,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(0),
+			},
+		}
+	}
+
+	response.Schema = schema.Schema{
+		Description: "Selects the machine type best matching the given requirements.",
+		MarkdownDescription: "Selects the machine type best matching the given requirements from the assets, see `metal_assets`. " +
+			"Fails if no machine type matches. Required permissions: `Asset List`.",
+		Attributes: map[string]schema.Attribute{
+			"min_cpus":    minimum("The minimum number of CPUs."),
+			"min_memory":  minimum("The minimum memory in bytes."),
+			"min_storage": minimum("The minimum storage in bytes."),
+			"region": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only select machine types offered in this region. Defaults to all regions.",
+			},
+			"prefer": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Select the `smallest` or the `largest` of all matching machine types, ordered by memory, CPUs and storage. Defaults to `smallest`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(preferSmallest, preferLargest),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The id of the machine type.",
+			},
+			"name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The name of the machine type.",
+			},
+			"cpus": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "CPUs in this machine.",
+			},
+			"memory": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Memory in this machine.",
+			},
+			"storage": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Storage in this machine.",
+			},
+			"cpu_description": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The description of the CPUs in this machine.",
+			},
+			"storage_description": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The description of the disks in this machine.",
+			},
+		},
+	}
+}
+
+func (m *MachineTypeDataSource) Configure(ctx context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
+	if request.ProviderData == nil {
+		return
+	}
+
+	client, ok := request.ProviderData.(*session.Session)
+	if !ok {
+		response.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *session.Session, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+		)
+		return
+	}
+
+	m.session = client
+}
+
+func (m *MachineTypeDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	var data machineTypeDataSourceModel
+	response.Diagnostics.Append(request.Config.Get(ctx, &data)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	assetResp, err := m.session.Client.Apiv1().Asset().List(ctx, connect.NewRequest(&apiv1.AssetServiceListRequest{}))
+	if err != nil {
+		response.Diagnostics.AddError("Failed to get assets list", err.Error())
+		return
+	}
+
+	selected, err := selectMachineType(
+		assetResp.Msg.Assets,
+		data.Region.ValueString(),
+		uint64(data.MinCpus.ValueInt64()),
+		uint64(data.MinMemory.ValueInt64()),
+		uint64(data.MinStorage.ValueInt64()),
+		data.Prefer.ValueString(),
+	)
+	if err != nil {
+		response.Diagnostics.AddError("No matching machine type", err.Error())
+		return
+	}
+
+	data.Id = types.StringValue(selected.Id)
+	data.Name = types.StringValue(selected.Name)
+	data.Cpus = types.Int64Value(int64(selected.Cpus))
+	data.Memory = types.Int64Value(int64(selected.Memory))
+	data.Storage = types.Int64Value(int64(selected.Storage))
+	data.CpuDescription = types.StringValue(selected.CpuDescription)
+	data.StorageDescription = types.StringValue(selected.StorageDescription)
+	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
+}
@@ -5,7 +5,12 @@ import (
 )
 
 type AssetListDataSourceModel struct {
-	Items []assetModel `tfsdk:"items"`
+	ContentId            types.String   `tfsdk:"id"`
+	Region               types.String   `tfsdk:"region"`
+	Partition            types.String   `tfsdk:"partition"`
+	MachineTypeIds       []types.String `tfsdk:"machine_type_ids"`
+	KubernetesMinVersion types.String   `tfsdk:"kubernetes_min_version"`
+	Items                []assetModel   `tfsdk:"items"`
 }
 
 type assetModel struct {
@@ -53,3 +58,35 @@ type machineType struct {
 type kubernetesVersion struct {
 	Version types.String `tfsdk:"version"`
 }
+
+type machineTypeDataSourceModel struct {
+	MinCpus            types.Int64  `tfsdk:"min_cpus"`
+	MinMemory          types.Int64  `tfsdk:"min_memory"`
+	MinStorage         types.Int64  `tfsdk:"min_storage"`
+	Region             types.String `tfsdk:"region"`
+	Prefer             types.String `tfsdk:"prefer"`
+	Id                 types.String `tfsdk:"id"`
+	Name               types.String `tfsdk:"name"`
+	Cpus               types.Int64  `tfsdk:"cpus"`
+	Memory             types.Int64  `tfsdk:"memory"`
+	Storage            types.Int64  `tfsdk:"storage"`
+	CpuDescription     types.String `tfsdk:"cpu_description"`
+	StorageDescription types.String `tfsdk:"storage_description"`
+}
+
+type kubernetesVersionDataSourceModel struct {
+	Constraint types.String   `tfsdk:"constraint"`
+	Region     types.String   `tfsdk:"region"`
+	Version    types.String   `tfsdk:"version"`
+	Versions   []types.String `tfsdk:"versions"`
+	IsDefault  types.Bool     `tfsdk:"is_default"`
+}
+
+type partitionDataSourceModel struct {
+	Id          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Region      types.String `tfsdk:"region"`
+	Address     types.String `tfsdk:"address"`
+	Active      types.Bool   `tfsdk:"active"`
+	Description types.String `tfsdk:"description"`
+}
@@ -0,0 +1,109 @@
+package asset
+
+import (
+	"context"
+	"fmt"
+
+	connect "connectrpc.com/connect"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	datasource "github.com/hashicorp/terraform-plugin-framework/datasource"
+	schema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	types "github.com/hashicorp/terraform-plugin-framework/types"
+	apiv1 "github.com/metal-stack-cloud/api/go/api/v1"
+	session "github.com/metal-stack-cloud/terraform-provider-metal/internal/session"
+)
+
+var (
+	_ datasource.DataSource              = &PartitionDataSource{}
+	_ datasource.DataSourceWithConfigure = &PartitionDataSource{}
+)
+
+func NewPartitionDataSource() datasource.DataSource {
+	return &PartitionDataSource{}
+}
+
+type PartitionDataSource struct {
+	session *session.Session
+}
+
+func (*PartitionDataSource) Metadata(ctx context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_partition"
+}
+
+func (*PartitionDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	attributes := partitionAttributes()
+	attributes["id"] = schema.StringAttribute{
+		Optional:            true,
+		Computed:            true,
+		MarkdownDescription: "The id of the partition.",
+		Validators: []validator.String{
+			stringvalidator.ExactlyOneOf(path.MatchRoot("id"), path.MatchRoot("name")),
+		},
+	}
+	attributes["name"] = schema.StringAttribute{
+		Optional:            true,
+		Computed:            true,
+		MarkdownDescription: "The name of the partition.",
+	}
+	attributes["region"] = schema.StringAttribute{
+		Optional:            true,
+		Computed:            true,
+		MarkdownDescription: "The id of the region of the partition. If set, only partitions of this region are looked up.",
+	}
+
+	response.Schema = schema.Schema{
+		Description:         "Looks up a single partition by id or name.",
+		MarkdownDescription: "Looks up a single partition by `id` or `name`. Fails if the partition is not active. Required permissions: `Asset List`.",
+		Attributes:          attributes,
+	}
+}
+
+func (p *PartitionDataSource) Configure(ctx context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
+	if request.ProviderData == nil {
+		return
+	}
+
+	client, ok := request.ProviderData.(*session.Session)
+	if !ok {
+		response.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *session.Session, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+		)
+		return
+	}
+
+	p.session = client
+}
+
+func (p *PartitionDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	var data partitionDataSourceModel
+	response.Diagnostics.Append(request.Config.Get(ctx, &data)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	assetResp, err := p.session.Client.Apiv1().Asset().List(ctx, connect.NewRequest(&apiv1.AssetServiceListRequest{}))
+	if err != nil {
+		response.Diagnostics.AddError("Failed to get assets list", err.Error())
+		return
+	}
+
+	found, region, err := findPartition(assetResp.Msg.Assets, data.Region.ValueString(), data.Id.ValueString(), data.Name.ValueString())
+	if err != nil {
+		response.Diagnostics.AddError("Failed to find partition", err.Error())
+		return
+	}
+
+	mapped := partitionFromApi(found)
+	data = partitionDataSourceModel{
+		Id:          mapped.Id,
+		Name:        mapped.Name,
+		Region:      types.StringValue(region),
+		Address:     mapped.Address,
+		Active:      mapped.Active,
+		Description: mapped.Description,
+	}
+	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,93 @@
+package asset
+
+import (
+	"context"
+	"fmt"
+
+	connect "connectrpc.com/connect"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	datasource "github.com/hashicorp/terraform-plugin-framework/datasource"
+	schema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	apiv1 "github.com/metal-stack-cloud/api/go/api/v1"
+	session "github.com/metal-stack-cloud/terraform-provider-metal/internal/session"
+)
+
+var (
+	_ datasource.DataSource              = &RegionDataSource{}
+	_ datasource.DataSourceWithConfigure = &RegionDataSource{}
+)
+
+func NewRegionDataSource() datasource.DataSource {
+	return &RegionDataSource{}
+}
+
+type RegionDataSource struct {
+	session *session.Session
+}
+
+func (*RegionDataSource) Metadata(ctx context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_region"
+}
+
+func (*RegionDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	attributes := regionAttributes()
+	attributes["id"] = schema.StringAttribute{
+		Optional:            true,
+		Computed:            true,
+		MarkdownDescription: "The id of the region.",
+		Validators: []validator.String{
+			stringvalidator.ExactlyOneOf(path.MatchRoot("id"), path.MatchRoot("name")),
+		},
+	}
+	attributes["name"] = schema.StringAttribute{
+		Optional:            true,
+		Computed:            true,
+		MarkdownDescription: "The name of the region.",
+	}
+
+	response.Schema = schema.Schema{
+		Description:         "Looks up a single region by id or name.",
+		MarkdownDescription: "Looks up a single region by `id` or `name`. Fails if the region is not active. Required permissions: `Asset List`.",
+		Attributes:          attributes,
+	}
+}
+
+func (r *RegionDataSource) Configure(ctx context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
+	if request.ProviderData == nil {
+		return
+	}
+
+	client, ok := request.ProviderData.(*session.Session)
+	if !ok {
+		response.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *session.Session, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+		)
+		return
+	}
+
+	r.session = client
+}
+
+func (r *RegionDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	var data region
+	response.Diagnostics.Append(request.Config.Get(ctx, &data)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	assetResp, err := r.session.Client.Apiv1().Asset().List(ctx, connect.NewRequest(&apiv1.AssetServiceListRequest{}))
+	if err != nil {
+		response.Diagnostics.AddError("Failed to get assets list", err.Error())
+		return
+	}
+
+	found, err := findRegion(assetResp.Msg.Assets, data.Id.ValueString(), data.Name.ValueString())
+	if err != nil {
+		response.Diagnostics.AddError("Failed to find region", err.Error())
+		return
+	}
+	response.Diagnostics.Append(response.State.Set(ctx, regionFromApi(found))...)
+}
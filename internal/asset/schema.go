@@ -9,82 +9,7 @@ func assetDataSourceAttributes() map[string]datasourceschema.Attribute {
 		"region": datasourceschema.SingleNestedAttribute{
 			Computed:    true,
 			Description: "The location of a datacenter.",
-			Attributes: map[string]datasourceschema.Attribute{
-				"id": datasourceschema.StringAttribute{
-					Computed:            true,
-					MarkdownDescription: "The id of the region.",
-				},
-				"name": datasourceschema.StringAttribute{
-					Computed:            true,
-					MarkdownDescription: "The name of the region.",
-				},
-				"address": datasourceschema.StringAttribute{
-					Computed:            true,
-					MarkdownDescription: "The address of the region.",
-				},
-				"active": datasourceschema.BoolAttribute{
-					Computed:            true,
-					MarkdownDescription: "Indicates if the region is usable.",
-				},
-				"partitions": datasourceschema.ListNestedAttribute{
-					Computed:            true,
-					MarkdownDescription: "Available partitions in this region",
-					NestedObject: datasourceschema.NestedAttributeObject{
-						Attributes: map[string]datasourceschema.Attribute{
-							"id": datasourceschema.StringAttribute{
-								Computed:            true,
-								MarkdownDescription: "The id of the partition.",
-							},
-							"name": datasourceschema.StringAttribute{
-								Computed:            true,
-								MarkdownDescription: "The name of the partition.",
-							},
-							"address": datasourceschema.StringAttribute{
-								Computed:            true,
-								MarkdownDescription: "The address of the partition.",
-							},
-							"active": datasourceschema.BoolAttribute{
-								Computed:            true,
-								MarkdownDescription: "Indicates if the partition is usable.",
-							},
-							"description": datasourceschema.StringAttribute{
-								Computed:            true,
-								MarkdownDescription: "Description of the partition.",
-							},
-						},
-					},
-				},
-				"defaults": datasourceschema.SingleNestedAttribute{
-					Computed:            true,
-					MarkdownDescription: "The defaults for assets, if not specified otherwise.",
-					Attributes: map[string]datasourceschema.Attribute{
-						"machine_type": datasourceschema.StringAttribute{
-							Computed:            true,
-							MarkdownDescription: "The default machine type used.",
-						},
-						"kubernetes_version": datasourceschema.StringAttribute{
-							Computed:            true,
-							MarkdownDescription: "The default Kubernetes version used.",
-						},
-						"worker_min": datasourceschema.Int64Attribute{
-							Computed:            true,
-							MarkdownDescription: "The minimum servers specified.",
-						},
-						"worker_max": datasourceschema.Int64Attribute{
-							Computed:            true,
-							MarkdownDescription: "The maximum servers specified.",
-						},
-						"partition": datasourceschema.StringAttribute{
-							Computed:            true,
-							MarkdownDescription: "The partition where the cluster is created by default.",
-						},
-					},
-				},
-				"description": datasourceschema.StringAttribute{
-					Computed:            true,
-					MarkdownDescription: "Description of the region.",
-				},
-			},
+			Attributes:  regionAttributes(),
 		},
 		"machine_types": datasourceschema.ListNestedAttribute{
 			Computed:    true,
@@ -136,3 +61,86 @@ func assetDataSourceAttributes() map[string]datasourceschema.Attribute {
 		},
 	}
 }
+
+func regionAttributes() map[string]datasourceschema.Attribute {
+	return map[string]datasourceschema.Attribute{
+		"id": datasourceschema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The id of the region.",
+		},
+		"name": datasourceschema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The name of the region.",
+		},
+		"address": datasourceschema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The address of the region.",
+		},
+		"active": datasourceschema.BoolAttribute{
+			Computed:            true,
+			MarkdownDescription: "Indicates if the region is usable.",
+		},
+		"partitions": datasourceschema.ListNestedAttribute{
+			Computed:            true,
+			MarkdownDescription: "Available partitions in this region",
+			NestedObject: datasourceschema.NestedAttributeObject{
+				Attributes: partitionAttributes(),
+			},
+		},
+		"defaults": datasourceschema.SingleNestedAttribute{
+			Computed:            true,
+			MarkdownDescription: "The defaults for assets, if not specified otherwise.",
+			Attributes: map[string]datasourceschema.Attribute{
+				"machine_type": datasourceschema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "The default machine type used.",
+				},
+				"kubernetes_version": datasourceschema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "The default Kubernetes version used.",
+				},
+				"worker_min": datasourceschema.Int64Attribute{
+					Computed:            true,
+					MarkdownDescription: "The minimum servers specified.",
+				},
+				"worker_max": datasourceschema.Int64Attribute{
+					Computed:            true,
+					MarkdownDescription: "The maximum servers specified.",
+				},
+				"partition": datasourceschema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "The partition where the cluster is created by default.",
+				},
+			},
+		},
+		"description": datasourceschema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Description of the region.",
+		},
+	}
+}
+
+func partitionAttributes() map[string]datasourceschema.Attribute {
+	return map[string]datasourceschema.Attribute{
+		"id": datasourceschema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The id of the partition.",
+		},
+		"name": datasourceschema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The name of the partition.",
+		},
+		"address": datasourceschema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The address of the partition.",
+		},
+		"active": datasourceschema.BoolAttribute{
+			Computed:            true,
+			MarkdownDescription: "Indicates if the partition is usable.",
+		},
+		"description": datasourceschema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Description of the partition.",
+		},
+	}
+}
@@ -0,0 +1,75 @@
+package audit
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	apiv1 "github.com/metal-stack-cloud/api/go/api/v1"
+	assert "github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func Test_auditListRequest(t *testing.T) {
+	data := AuditTracesDataSourceModel{
+		Tenant:     basetypes.NewStringValue("acme@github"),
+		From:       basetypes.NewStringValue("2024-02-08T08:48:20Z"),
+		To:         basetypes.NewStringNull(),
+		User:       basetypes.NewStringValue("octocat@github"),
+		Method:     basetypes.NewStringNull(),
+		Project:    basetypes.NewStringNull(),
+		ResultCode: basetypes.NewInt64Value(0),
+		Limit:      basetypes.NewInt64Null(),
+	}
+	user := "octocat@github"
+	resultCode := int32(0)
+	limit := int32(defaultLimit)
+	want := &apiv1.AuditServiceListRequest{
+		Login:      "acme@github",
+		From:       &timestamppb.Timestamp{Seconds: int64(1707382100)},
+		User:       &user,
+		ResultCode: &resultCode,
+		Limit:      &limit,
+	}
+
+	got, err := auditListRequest(data)
+	assert.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	data.To = basetypes.NewStringValue("yesterday")
+	_, err = auditListRequest(data)
+	assert.ErrorContains(t, err, "to is no RFC3339 timestamp")
+}
+
+func Test_auditTraceFromApi(t *testing.T) {
+	project := "7c8f1d2e-3a4b-4c5d-8e6f-9a0b1c2d3e4f"
+	resultCode := int32(7)
+	trace := &apiv1.AuditTrace{
+		Uuid: "a1b2c3d4-e5f6-4a7b-8c9d-0e1f2a3b4c5d",
+		Timestamp: &timestamppb.Timestamp{
+			Seconds: int64(1707382100),
+		},
+		User:       "octocat@github",
+		Tenant:     "acme@github",
+		Project:    &project,
+		Method:     "/api.v1.ClusterService/Update",
+		SourceIp:   "203.0.113.7",
+		ResultCode: &resultCode,
+	}
+	want := auditTraceModel{
+		RequestId:  basetypes.NewStringValue("a1b2c3d4-e5f6-4a7b-8c9d-0e1f2a3b4c5d"),
+		Timestamp:  basetypes.NewStringValue("2024-02-08 08:48:20 +0000 UTC"),
+		User:       basetypes.NewStringValue("octocat@github"),
+		Tenant:     basetypes.NewStringValue("acme@github"),
+		Project:    basetypes.NewStringValue("7c8f1d2e-3a4b-4c5d-8e6f-9a0b1c2d3e4f"),
+		Method:     basetypes.NewStringValue("/api.v1.ClusterService/Update"),
+		SourceIp:   basetypes.NewStringValue("203.0.113.7"),
+		ResultCode: basetypes.NewInt64Value(7),
+	}
+	assert.Equal(t, want, auditTraceFromApi(trace))
+
+	trace.Project = nil
+	trace.ResultCode = nil
+	got := auditTraceFromApi(trace)
+	assert.True(t, got.Project.IsNull())
+	assert.True(t, got.ResultCode.IsNull())
+}
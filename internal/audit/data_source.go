@@ -0,0 +1,187 @@
+package audit
+
+import (
+	"context"
+	"crypto/sha1"
+	"fmt"
+	"math"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/metal-stack-cloud/terraform-provider-metal/internal/session"
+)
+
+var (
+	_ datasource.DataSource              = &AuditTracesDataSource{}
+	_ datasource.DataSourceWithConfigure = &AuditTracesDataSource{}
+)
+
+func NewAuditTracesDataSource() datasource.DataSource {
+	return &AuditTracesDataSource{}
+}
+
+// AuditTracesDataSource defines the data source implementation.
+type AuditTracesDataSource struct {
+	session *session.Session
+}
+
+// Metadata implements datasource.DataSource.
+func (*AuditTracesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_audit_traces"
+}
+
+// Schema implements datasource.DataSource.
+func (*AuditTracesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists who called which method of the API. Each call is traced once for the request and once for the response. \n" +
+			"Request and response bodies are not exposed, as they may contain secrets. \n" +
+			"Required permissions: `Audit List`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"tenant": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The tenant (organization) to list the traces of. Defaults to the tenant owning the provider project.",
+			},
+			"from": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list traces at or after this RFC3339 timestamp, e.g. from `timeadd(plantimestamp(), \"-24h\")`.",
+			},
+			"to": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list traces before this RFC3339 timestamp.",
+			},
+			"user": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list traces of this user, e.g. `octocat@github`.",
+			},
+			"method": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list traces of this method, e.g. `/api.v1.ClusterService/Update`.",
+			},
+			"project": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list traces of this project.",
+			},
+			"result_code": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Only list responses with this connect result code, e.g. 0 for successful calls or 7 for denied ones.",
+			},
+			"limit": schema.Int64Attribute{
+				Optional:    true,
+				Description: fmt.Sprintf("The maximum number of traces to list. Defaults to %d.", defaultLimit),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+					int64validator.AtMost(math.MaxInt32),
+				},
+			},
+			"traces": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "All matching traces.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"request_id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The ID of the call, shared by the traces of its request and response.",
+						},
+						"timestamp": schema.StringAttribute{
+							Computed:    true,
+							Description: "Indicates when the request was received or the response sent.",
+						},
+						"user": schema.StringAttribute{
+							Computed:    true,
+							Description: "The user who called the method.",
+						},
+						"tenant": schema.StringAttribute{
+							Computed:    true,
+							Description: "The tenant of the call.",
+						},
+						"project": schema.StringAttribute{
+							Computed:    true,
+							Description: "The project of the call, if the method is scoped to a project.",
+						},
+						"method": schema.StringAttribute{
+							Computed:    true,
+							Description: "The called method.",
+						},
+						"source_ip": schema.StringAttribute{
+							Computed:    true,
+							Description: "The IP address the call came from.",
+						},
+						"result_code": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The connect result code of a response, null for requests.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure implements datasource.DataSourceWithConfigure.
+func (a *AuditTracesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	session, ok := req.ProviderData.(*session.Session)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *session.Session, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	a.session = session
+}
+
+// Read implements datasource.DataSource.
+func (a *AuditTracesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AuditTracesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Tenant.ValueString() == "" {
+		tenant, err := a.session.ProviderTenant(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to determine the tenant of the provider project", err.Error())
+			return
+		}
+		data.Tenant = types.StringValue(tenant)
+	}
+
+	listReq, err := auditListRequest(data)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid audit trace filter", err.Error())
+		return
+	}
+	listResp, err := a.session.Client.Apiv1().Audit().List(ctx, connect.NewRequest(listReq))
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read audit traces", err.Error())
+		return
+	}
+	tflog.Trace(ctx, "read audit traces")
+
+	data.Traces = make([]auditTraceModel, 0, len(listResp.Msg.Traces))
+	ids := make([]string, 0, len(listResp.Msg.Traces))
+	for _, trace := range listResp.Msg.Traces {
+		data.Traces = append(data.Traces, auditTraceFromApi(trace))
+		ids = append(ids, trace.Uuid+trace.Timestamp.AsTime().String())
+	}
+
+	dataId := fmt.Sprintf("%x", sha1.Sum([]byte(strings.Join(ids, ""))))
+	data.ContentId = types.StringValue(dataId)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
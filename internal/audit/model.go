@@ -0,0 +1,98 @@
+package audit
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	apiv1 "github.com/metal-stack-cloud/api/go/api/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// defaultLimit keeps the state small if no limit is configured.
+const defaultLimit = 100
+
+// AuditTracesDataSourceModel describes the data source data model.
+type AuditTracesDataSourceModel struct {
+	ContentId  types.String      `tfsdk:"id"`
+	Tenant     types.String      `tfsdk:"tenant"`
+	From       types.String      `tfsdk:"from"`
+	To         types.String      `tfsdk:"to"`
+	User       types.String      `tfsdk:"user"`
+	Method     types.String      `tfsdk:"method"`
+	Project    types.String      `tfsdk:"project"`
+	ResultCode types.Int64       `tfsdk:"result_code"`
+	Limit      types.Int64       `tfsdk:"limit"`
+	Traces     []auditTraceModel `tfsdk:"traces"`
+}
+
+type auditTraceModel struct {
+	RequestId  types.String `tfsdk:"request_id"`
+	Timestamp  types.String `tfsdk:"timestamp"`
+	User       types.String `tfsdk:"user"`
+	Tenant     types.String `tfsdk:"tenant"`
+	Project    types.String `tfsdk:"project"`
+	Method     types.String `tfsdk:"method"`
+	SourceIp   types.String `tfsdk:"source_ip"`
+	ResultCode types.Int64  `tfsdk:"result_code"`
+}
+
+// auditListRequest converts the configured filters, the tenant must be set already.
+func auditListRequest(data AuditTracesDataSourceModel) (*apiv1.AuditServiceListRequest, error) {
+	req := &apiv1.AuditServiceListRequest{
+		Login:      data.Tenant.ValueString(),
+		User:       data.User.ValueStringPointer(),
+		Method:     data.Method.ValueStringPointer(),
+		Project:    data.Project.ValueStringPointer(),
+		ResultCode: int32Pointer(data.ResultCode),
+		Limit:      int32Pointer(data.Limit),
+	}
+	if req.Limit == nil {
+		limit := int32(defaultLimit)
+		req.Limit = &limit
+	}
+	var err error
+	if req.From, err = timestampFromString(data.From); err != nil {
+		return nil, fmt.Errorf("from is no RFC3339 timestamp: %w", err)
+	}
+	if req.To, err = timestampFromString(data.To); err != nil {
+		return nil, fmt.Errorf("to is no RFC3339 timestamp: %w", err)
+	}
+	return req, nil
+}
+
+func auditTraceFromApi(t *apiv1.AuditTrace) auditTraceModel {
+	model := auditTraceModel{
+		RequestId:  types.StringValue(t.Uuid),
+		Timestamp:  types.StringValue(t.Timestamp.AsTime().String()),
+		User:       types.StringValue(t.User),
+		Tenant:     types.StringValue(t.Tenant),
+		Project:    types.StringPointerValue(t.Project),
+		Method:     types.StringValue(t.Method),
+		SourceIp:   types.StringValue(t.SourceIp),
+		ResultCode: types.Int64Null(),
+	}
+	if t.ResultCode != nil {
+		model.ResultCode = types.Int64Value(int64(*t.ResultCode))
+	}
+	return model
+}
+
+func timestampFromString(value types.String) (*timestamppb.Timestamp, error) {
+	if value.IsNull() {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value.ValueString())
+	if err != nil {
+		return nil, err
+	}
+	return timestamppb.New(t), nil
+}
+
+func int32Pointer(value types.Int64) *int32 {
+	if value.IsNull() {
+		return nil
+	}
+	i := int32(value.ValueInt64())
+	return &i
+}
@@ -0,0 +1,105 @@
+package cluster
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	types "github.com/hashicorp/terraform-plugin-framework/types"
+	apiv1 "github.com/metal-stack-cloud/api/go/api/v1"
+)
+
+// checkClusterAssets returns attribute errors if the partition is unknown or inactive, or if a worker group uses a
+// machine type not offered in the region of the partition. Unknown values are skipped, without a partition the
+// machine types of all regions are valid. The partition is only checked if checkPartition is set and only the
+// machine types of the workers marked in checkWorkers are checked, all of them if it is nil.
+func checkClusterAssets(assets []*apiv1.Asset, partition types.String, checkPartition bool, workers []clusterWorkerModel, checkWorkers []bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var activePartitions []string
+	for _, a := range assets {
+		for _, p := range a.Region.Partitions {
+			if p.Active {
+				activePartitions = append(activePartitions, p.Id)
+			}
+		}
+	}
+	slices.Sort(activePartitions)
+
+	var region *apiv1.Asset
+	if !partition.IsUnknown() && partition.ValueString() != "" {
+		var found *apiv1.Partition
+		for _, a := range assets {
+			if p, ok := a.Region.Partitions[partition.ValueString()]; ok {
+				found, region = p, a
+				break
+			}
+		}
+		switch {
+		case !checkPartition:
+		case found == nil:
+			diags.AddAttributeError(
+				path.Root("partition"),
+				"Unknown partition",
+				fmt.Sprintf("The partition %q does not exist. Valid partitions are: %s.", partition.ValueString(), strings.Join(activePartitions, ", ")),
+			)
+		case !found.Active:
+			diags.AddAttributeError(
+				path.Root("partition"),
+				"Partition is not active",
+				fmt.Sprintf("The partition %q does not accept new clusters. Valid partitions are: %s.", partition.ValueString(), strings.Join(activePartitions, ", ")),
+			)
+		}
+	}
+
+	offered := map[string]bool{}
+	for _, a := range assets {
+		if region != nil && a != region {
+			continue
+		}
+		for _, m := range a.MachineTypes {
+			offered[m.Id] = true
+		}
+	}
+	var validMachineTypes []string
+	for id := range offered {
+		validMachineTypes = append(validMachineTypes, id)
+	}
+	slices.Sort(validMachineTypes)
+
+	for i, w := range workers {
+		if checkWorkers != nil && !checkWorkers[i] {
+			continue
+		}
+		if w.MachineType.IsUnknown() || offered[w.MachineType.ValueString()] {
+			continue
+		}
+		where := "any region"
+		if region != nil {
+			where = fmt.Sprintf("the region %q", region.Region.Id)
+		}
+		diags.AddAttributeError(
+			path.Root("workers").AtListIndex(i).AtName("machine_type"),
+			"Machine type not offered",
+			fmt.Sprintf("The machine type %q is not offered in %s. Valid machine types are: %s.", w.MachineType.ValueString(), where, strings.Join(validMachineTypes, ", ")),
+		)
+	}
+	return diags
+}
+
+// changedMachineTypes returns for every planned worker group if it is new or its machine type changed.
+// Worker groups are matched by name, so resizing them or changing the order does not count as change.
+func changedMachineTypes(planned, current []clusterWorkerModel) []bool {
+	machineTypes := make(map[string]types.String, len(current))
+	for _, w := range current {
+		machineTypes[w.Name.ValueString()] = w.MachineType
+	}
+	changed := make([]bool, len(planned))
+	for i, w := range planned {
+		machineType, ok := machineTypes[w.Name.ValueString()]
+		changed[i] = !ok || !machineType.Equal(w.MachineType)
+	}
+	return changed
+}
@@ -3,6 +3,8 @@ package cluster
 import (
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	resource "github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	apiv1 "github.com/metal-stack-cloud/api/go/api/v1"
@@ -257,3 +259,131 @@ func Test_clusterResponseMapping(t *testing.T) {
 		})
 	}
 }
+
+func Test_clusterPricesEstimate(t *testing.T) {
+	prices := clusterPricesFromApi([]*apiv1.Price{
+		{Name: "N1 Medium", ProductId: "n1-medium-x86", UnitLabel: "hour", UnitAmountDecimal: 0.1, ProductType: apiv1.ProductType_PRODUCT_TYPE_COMPUTE},
+		{Name: "C1 Large", ProductId: "c1-large-x86", UnitLabel: "month", UnitAmountDecimal: 150, ProductType: apiv1.ProductType_PRODUCT_TYPE_COMPUTE},
+		{Name: "G1 Small", ProductId: "g1-small-x86", UnitLabel: "GiB", UnitAmountDecimal: 0.05, ProductType: apiv1.ProductType_PRODUCT_TYPE_COMPUTE},
+		{Name: "Kubernetes Control Plane", ProductId: "control-plane", UnitLabel: "Month", UnitAmountDecimal: 20, ProductType: apiv1.ProductType_PRODUCT_TYPE_KUBERNETES},
+		{Name: "Storage", ProductId: "storage", UnitLabel: "GiB", UnitAmountDecimal: 0.05, ProductType: apiv1.ProductType_PRODUCT_TYPE_STORAGE},
+	})
+	assert.Equal(t, monthlyPrice{amount: 73}, prices.machineTypes["n1-medium-x86"])
+	assert.Equal(t, monthlyPrice{amount: 150}, prices.machineTypes["c1-large-x86"])
+	assert.Equal(t, monthlyPrice{amount: 20}, *prices.controlPlane)
+
+	workers := []clusterCostWorkerModel{
+		{
+			MachineType: basetypes.NewStringValue("n1-medium-x86"),
+			Minsize:     basetypes.NewInt64Value(1),
+			Maxsize:     basetypes.NewInt64Value(3),
+		},
+		{
+			MachineType: basetypes.NewStringValue("c1-large-x86"),
+			Minsize:     basetypes.NewInt64Value(0),
+			Maxsize:     basetypes.NewInt64Value(2),
+		},
+	}
+	minMonthly, maxMonthly, err := prices.estimate(workers)
+	assert.NoError(t, err)
+	assert.Equal(t, 93.0, minMonthly)
+	assert.Equal(t, 539.0, maxMonthly)
+	assert.Equal(t, basetypes.NewFloat64Value(73), workers[0].MinMonthly)
+	assert.Equal(t, basetypes.NewFloat64Value(219), workers[0].MaxMonthly)
+	assert.Equal(t, basetypes.NewFloat64Value(0), workers[1].MinMonthly)
+	assert.Equal(t, basetypes.NewFloat64Value(300), workers[1].MaxMonthly)
+
+	_, _, err = prices.estimate([]clusterCostWorkerModel{{MachineType: basetypes.NewStringValue("unknown")}})
+	assert.EqualError(t, err, `no price found for machine type "unknown"`)
+
+	unconvertible := []clusterCostWorkerModel{{MachineType: basetypes.NewStringValue("g1-small-x86"), Minsize: basetypes.NewInt64Value(1)}}
+	_, _, err = prices.estimate(unconvertible)
+	assert.EqualError(t, err, `the price "G1 Small" is given per "GiB", which cannot be converted into monthly costs`)
+	assert.True(t, unconvertible[0].MinMonthly.IsNull())
+
+	_, _, err = clusterPricesFromApi(nil).estimate(nil)
+	assert.EqualError(t, err, `no price found for the Kubernetes control plane`)
+
+	_, _, err = clusterPricesFromApi([]*apiv1.Price{
+		{Name: "Kubernetes Control Plane", UnitLabel: "month", UnitAmountDecimal: 20, ProductType: apiv1.ProductType_PRODUCT_TYPE_KUBERNETES},
+		{Name: "Kubernetes Audit", UnitLabel: "month", UnitAmountDecimal: 5, ProductType: apiv1.ProductType_PRODUCT_TYPE_KUBERNETES},
+	}).estimate(nil)
+	assert.EqualError(t, err, `the control plane price cannot be told apart from the Kubernetes prices Kubernetes Control Plane, Kubernetes Audit`)
+}
+
+func Test_checkClusterAssets(t *testing.T) {
+	assets := []*apiv1.Asset{
+		{
+			Region: &apiv1.Region{
+				Id: "muc",
+				Partitions: map[string]*apiv1.Partition{
+					"eqx-mu4": {Id: "eqx-mu4", Active: true},
+					"eqx-mu5": {Id: "eqx-mu5"},
+				},
+			},
+			MachineTypes: map[string]*apiv1.MachineType{
+				"n1-medium-x86": {Id: "n1-medium-x86"},
+				"c1-large-x86":  {Id: "c1-large-x86"},
+			},
+		},
+		{
+			Region: &apiv1.Region{
+				Id:         "fra",
+				Partitions: map[string]*apiv1.Partition{"eqx-fr2": {Id: "eqx-fr2", Active: true}},
+			},
+			MachineTypes: map[string]*apiv1.MachineType{
+				"c1-xlarge-x86": {Id: "c1-xlarge-x86"},
+			},
+		},
+	}
+	worker := func(machineType string) clusterWorkerModel {
+		return clusterWorkerModel{MachineType: basetypes.NewStringValue(machineType)}
+	}
+
+	diags := checkClusterAssets(assets, basetypes.NewStringValue("eqx-mu4"), true, []clusterWorkerModel{worker("n1-medium-x86")}, nil)
+	assert.False(t, diags.HasError())
+
+	diags = checkClusterAssets(assets, basetypes.NewStringUnknown(), true, []clusterWorkerModel{worker("c1-xlarge-x86"), {MachineType: basetypes.NewStringUnknown()}}, nil)
+	assert.False(t, diags.HasError())
+
+	diags = checkClusterAssets(assets, basetypes.NewStringValue("eqx-mu5"), true, nil, nil)
+	assert.Len(t, diags, 1)
+	assert.Equal(t, "Partition is not active", diags[0].Summary())
+	assert.Equal(t, `The partition "eqx-mu5" does not accept new clusters. Valid partitions are: eqx-fr2, eqx-mu4.`, diags[0].Detail())
+
+	diags = checkClusterAssets(assets, basetypes.NewStringValue("eqx-mu4"), true, []clusterWorkerModel{worker("n1-medium-x86"), worker("c1-xlarge-x86")}, nil)
+	assert.Len(t, diags, 1)
+	assert.Equal(t, "Machine type not offered", diags[0].Summary())
+	assert.Equal(t, `The machine type "c1-xlarge-x86" is not offered in the region "muc". Valid machine types are: c1-large-x86, n1-medium-x86.`, diags[0].Detail())
+
+	diags = checkClusterAssets(assets, basetypes.NewStringValue("eqx-ber1"), true, nil, nil)
+	assert.Len(t, diags, 1)
+	assert.Equal(t, "Unknown partition", diags[0].Summary())
+
+	// an existing cluster in an inactive partition only gets its changed machine types checked
+	diags = checkClusterAssets(assets, basetypes.NewStringValue("eqx-mu5"), false, []clusterWorkerModel{worker("c1-xlarge-x86"), worker("c1-xlarge-x86")}, []bool{false, true})
+	assert.Len(t, diags, 1)
+	assert.Equal(t, path.Root("workers").AtListIndex(1).AtName("machine_type"), diags[0].(diag.DiagnosticWithPath).Path())
+}
+
+func Test_changedMachineTypes(t *testing.T) {
+	worker := func(name, machineType string, maxsize int64) clusterWorkerModel {
+		return clusterWorkerModel{
+			Name:        basetypes.NewStringValue(name),
+			MachineType: basetypes.NewStringValue(machineType),
+			Maxsize:     basetypes.NewInt64Value(maxsize),
+		}
+	}
+	current := []clusterWorkerModel{
+		worker("default", "n1-medium-x86", 3),
+		worker("large", "c1-large-x86", 2),
+	}
+	planned := []clusterWorkerModel{
+		worker("large", "c1-xlarge-x86", 2),
+		worker("default", "n1-medium-x86", 5),
+		worker("new", "n1-medium-x86", 1),
+	}
+
+	assert.Equal(t, []bool{true, false, true}, changedMachineTypes(planned, current))
+	assert.Equal(t, []bool{false, false}, changedMachineTypes(current, current))
+}
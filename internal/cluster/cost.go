@@ -0,0 +1,137 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"connectrpc.com/connect"
+	types "github.com/hashicorp/terraform-plugin-framework/types"
+	apiv1 "github.com/metal-stack-cloud/api/go/api/v1"
+	session "github.com/metal-stack-cloud/terraform-provider-metal/internal/session"
+)
+
+// hoursPerMonth converts hourly prices into monthly costs, an average month has 730 hours.
+const hoursPerMonth = 730
+
+// billingHours are the hours billed by a price per unit label, prices with other units cannot be converted into monthly costs.
+var billingHours = map[string]float64{
+	"hour":  1,
+	"month": hoursPerMonth,
+}
+
+// monthlyPrice is the monthly amount of a price, or the reason why it cannot be determined.
+type monthlyPrice struct {
+	amount float64
+	err    error
+}
+
+// clusterPrices holds the monthly price of a single machine per machine type and the monthly price of the control plane.
+type clusterPrices struct {
+	machineTypes map[string]monthlyPrice
+	controlPlane *monthlyPrice
+}
+
+func getClusterPrices(ctx context.Context, s *session.Session) (*clusterPrices, error) {
+	resp, err := s.Client.Apiv1().Payment().GetDefaultPrices(ctx, connect.NewRequest(&apiv1.PaymentServiceGetDefaultPricesRequest{}))
+	if err != nil {
+		return nil, err
+	}
+	return clusterPricesFromApi(resp.Msg.Prices), nil
+}
+
+// clusterPricesFromApi picks the prices of the machine types by their product IDs and the price of the control plane,
+// which is the only Kubernetes product. All other products are not part of the estimate.
+func clusterPricesFromApi(prices []*apiv1.Price) *clusterPrices {
+	result := &clusterPrices{
+		machineTypes: map[string]monthlyPrice{},
+	}
+	var kubernetes []string
+	for _, p := range prices {
+		switch p.ProductType {
+		case apiv1.ProductType_PRODUCT_TYPE_COMPUTE:
+			result.machineTypes[p.ProductId] = monthlyPriceFromApi(p)
+		case apiv1.ProductType_PRODUCT_TYPE_KUBERNETES:
+			price := monthlyPriceFromApi(p)
+			result.controlPlane = &price
+			kubernetes = append(kubernetes, p.Name)
+		}
+	}
+	if len(kubernetes) > 1 {
+		result.controlPlane = &monthlyPrice{err: fmt.Errorf("the control plane price cannot be told apart from the Kubernetes prices %s", strings.Join(kubernetes, ", "))}
+	}
+	return result
+}
+
+// monthlyPriceFromApi converts a price into a monthly amount by its unit label.
+func monthlyPriceFromApi(p *apiv1.Price) monthlyPrice {
+	hours, ok := billingHours[strings.ToLower(strings.TrimSpace(p.UnitLabel))]
+	if !ok {
+		return monthlyPrice{err: fmt.Errorf("the price %q is given per %q, which cannot be converted into monthly costs", p.Name, p.UnitLabel)}
+	}
+	return monthlyPrice{amount: p.UnitAmountDecimal * hoursPerMonth / hours}
+}
+
+// estimate computes the monthly costs of every worker group and the total costs of the cluster with all groups
+// at their minimum and maximum size. The workers are only changed if all prices are known.
+func (p *clusterPrices) estimate(workers []clusterCostWorkerModel) (minMonthly, maxMonthly float64, err error) {
+	if p.controlPlane == nil {
+		return 0, 0, fmt.Errorf("no price found for the Kubernetes control plane")
+	}
+	if p.controlPlane.err != nil {
+		return 0, 0, p.controlPlane.err
+	}
+	minMonthly, maxMonthly = p.controlPlane.amount, p.controlPlane.amount
+	estimated := slices.Clone(workers)
+	for i, w := range estimated {
+		price, ok := p.machineTypes[w.MachineType.ValueString()]
+		if !ok {
+			return 0, 0, fmt.Errorf("no price found for machine type %q", w.MachineType.ValueString())
+		}
+		if price.err != nil {
+			return 0, 0, price.err
+		}
+		workerMin := roundCents(price.amount * float64(w.Minsize.ValueInt64()))
+		workerMax := roundCents(price.amount * float64(w.Maxsize.ValueInt64()))
+		estimated[i].MinMonthly = types.Float64Value(workerMin)
+		estimated[i].MaxMonthly = types.Float64Value(workerMax)
+		minMonthly += workerMin
+		maxMonthly += workerMax
+	}
+	copy(workers, estimated)
+	return roundCents(minMonthly), roundCents(maxMonthly), nil
+}
+
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+// listMachineTypes returns the IDs of all machine types offered in any region.
+func listMachineTypes(ctx context.Context, s *session.Session) (map[string]bool, error) {
+	resp, err := s.Client.Apiv1().Asset().List(ctx, connect.NewRequest(&apiv1.AssetServiceListRequest{}))
+	if err != nil {
+		return nil, err
+	}
+	machineTypes := map[string]bool{}
+	for _, asset := range resp.Msg.Assets {
+		for id := range asset.MachineTypes {
+			machineTypes[id] = true
+		}
+	}
+	return machineTypes, nil
+}
+
+func costWorkersFromCluster(workers []clusterWorkerModel) []clusterCostWorkerModel {
+	result := make([]clusterCostWorkerModel, 0, len(workers))
+	for _, w := range workers {
+		result = append(result, clusterCostWorkerModel{
+			Name:        w.Name,
+			MachineType: w.MachineType,
+			Minsize:     w.Minsize,
+			Maxsize:     w.Maxsize,
+		})
+	}
+	return result
+}
@@ -0,0 +1,128 @@
+package cluster
+
+import (
+	"context"
+	"crypto/sha1"
+	"fmt"
+	"strings"
+
+	datasource "github.com/hashicorp/terraform-plugin-framework/datasource"
+	schema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	types "github.com/hashicorp/terraform-plugin-framework/types"
+	session "github.com/metal-stack-cloud/terraform-provider-metal/internal/session"
+)
+
+var (
+	_ datasource.DataSource              = &ClusterCostEstimateDataSource{}
+	_ datasource.DataSourceWithConfigure = &ClusterCostEstimateDataSource{}
+)
+
+func NewClusterCostEstimateDataSource() datasource.DataSource {
+	return &ClusterCostEstimateDataSource{}
+}
+
+type ClusterCostEstimateDataSource struct {
+	session *session.Session
+}
+
+// Metadata implements datasource.DataSource.
+func (*ClusterCostEstimateDataSource) Metadata(ctx context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_cluster_cost_estimate"
+}
+
+// Schema implements datasource.DataSource.
+func (*ClusterCostEstimateDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Attributes:  clusterCostEstimateDataSourceAttributes(),
+		Description: "Estimates the monthly costs of a cluster from the default prices of its control plane and machine types.",
+		MarkdownDescription: "Estimates the monthly costs of a cluster from the default prices of its control plane and machine types. " +
+			"Either `workers` or the `cluster_id` of an existing cluster is required. " +
+			"Costs are given in the currency of the price list, without discounts and without volumes, snapshots, IP addresses and traffic. " +
+			"They are left empty with a warning if the price list does not allow an estimate, e.g. for prices not given per hour or month. \n" +
+			"Required permissions: `Payment GetDefaultPrices`, `Asset List`, and `Cluster Get` if `cluster_id` is set.",
+	}
+}
+
+// Configure implements datasource.DataSourceWithConfigure.
+func (c *ClusterCostEstimateDataSource) Configure(ctx context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
+	if request.ProviderData == nil {
+		return
+	}
+
+	client, ok := request.ProviderData.(*session.Session)
+	if !ok {
+		response.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *session.Session, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+		)
+		return
+	}
+
+	c.session = client
+}
+
+// Read implements datasource.DataSource.
+func (c *ClusterCostEstimateDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	var data clusterCostEstimateModel
+	response.Diagnostics.Append(request.Config.Get(ctx, &data)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	if data.ClusterId.ValueString() != "" {
+		project := data.Project.ValueString()
+		if project == "" {
+			project = c.session.Project
+		}
+		cluster, err := getCluster(ctx, c.session, project, data.ClusterId.ValueString())
+		if err != nil {
+			response.Diagnostics.AddError("Failed to get cluster", err.Error())
+			return
+		}
+		data.Workers = costWorkersFromCluster(clusterResponseMapping(cluster).Workers)
+	}
+
+	machineTypes, err := listMachineTypes(ctx, c.session)
+	if err != nil {
+		response.Diagnostics.AddError("Failed to get assets list", err.Error())
+		return
+	}
+	for i, w := range data.Workers {
+		if !machineTypes[w.MachineType.ValueString()] {
+			response.Diagnostics.AddAttributeError(
+				path.Root("workers").AtListIndex(i).AtName("machine_type"),
+				"Unknown machine type",
+				fmt.Sprintf("The machine type %q is not offered in any region, see the metal_assets data source.", w.MachineType.ValueString()),
+			)
+		}
+	}
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	prices, err := getClusterPrices(ctx, c.session)
+	if err != nil {
+		response.Diagnostics.AddError("Failed to get prices", err.Error())
+		return
+	}
+	data.Id = types.StringValue(costEstimateId(data.Workers))
+	minMonthly, maxMonthly, err := prices.estimate(data.Workers)
+	if err != nil {
+		// better no costs than wrong ones
+		response.Diagnostics.AddWarning("Cannot estimate cluster costs", err.Error()+". The costs are left empty.")
+	} else {
+		data.ControlPlaneMonthly = types.Float64Value(roundCents(prices.controlPlane.amount))
+		data.MinMonthly = types.Float64Value(minMonthly)
+		data.MaxMonthly = types.Float64Value(maxMonthly)
+	}
+	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
+}
+
+func costEstimateId(workers []clusterCostWorkerModel) string {
+	specs := make([]string, 0, len(workers))
+	for _, w := range workers {
+		specs = append(specs, fmt.Sprintf("%s:%d:%d", w.MachineType.ValueString(), w.Minsize.ValueInt64(), w.Maxsize.ValueInt64()))
+	}
+	return fmt.Sprintf("%x", sha1.Sum([]byte(strings.Join(specs, ","))))
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"connectrpc.com/connect"
 	datasource "github.com/hashicorp/terraform-plugin-framework/datasource"
 	schema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	apiv1 "github.com/metal-stack-cloud/api/go/api/v1"
@@ -74,17 +73,13 @@ func (c *ClusterDataSource) Read(ctx context.Context, request datasource.ReadReq
 	// get all clusters and select cluster by name if uuid is not set
 	var uuidString string
 	if data.Uuid.ValueString() == "" {
-		listRequestMessage := &apiv1.ClusterServiceListRequest{
-			Project: project,
-		}
 		// get clusterList type Clusters []*Cluster
-		clusterList, err := c.session.Client.Apiv1().Cluster().List(ctx, connect.NewRequest(listRequestMessage))
+		list, err := listClusters(ctx, c.session, project)
 		if err != nil {
 			response.Diagnostics.AddError("Failed to get cluster list", err.Error())
 			return
 		}
 		// find uuid and set uuidString
-		list := clusterList.Msg.Clusters
 		uuidStr, err := findUuidByName(list, data.Name.ValueString())
 		if err != nil {
 			response.Diagnostics.AddError(fmt.Sprintf("Failed to find cluster with name %v", data.Name.ValueString()), err.Error())
@@ -97,18 +92,14 @@ func (c *ClusterDataSource) Read(ctx context.Context, request datasource.ReadReq
 	}
 
 	// get Cluster by uuid
-	getRequestMessage := &apiv1.ClusterServiceGetRequest{
-		Uuid:    uuidString,
-		Project: project,
-	}
-	clientResponse, err := c.session.Client.Apiv1().Cluster().Get(ctx, connect.NewRequest(getRequestMessage))
+	cluster, err := getCluster(ctx, c.session, project, uuidString)
 	if err != nil {
 		response.Diagnostics.AddError("Failed to get cluster", err.Error())
 		return
 	}
 
 	// save updated data into terraform state
-	state := response.State.Set(ctx, clusterResponseMapping(clientResponse.Msg.Cluster))
+	state := response.State.Set(ctx, clusterResponseMapping(cluster))
 	response.Diagnostics.Append(state...)
 }
 
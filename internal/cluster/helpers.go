@@ -14,6 +14,8 @@ import (
 	types "github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	apiv1 "github.com/metal-stack-cloud/api/go/api/v1"
+	"github.com/metal-stack-cloud/api/go/api/v1/apiv1connect"
+	session "github.com/metal-stack-cloud/terraform-provider-metal/internal/session"
 	"google.golang.org/protobuf/types/known/durationpb"
 )
 
@@ -271,6 +273,43 @@ func clusterOperationWaitStatus(ctx context.Context, c *ClusterResource, statusR
 	}
 }
 
+// listClusters returns all clusters of the project, served from the session cache if enabled.
+func listClusters(ctx context.Context, s *session.Session, project string) ([]*apiv1.Cluster, error) {
+	return session.CachedList(ctx, s.Cache, apiv1connect.ClusterServiceName, project, func(ctx context.Context) ([]*apiv1.Cluster, error) {
+		clusterList, err := s.Client.Apiv1().Cluster().List(ctx, connect.NewRequest(&apiv1.ClusterServiceListRequest{
+			Project: project,
+		}))
+		if err != nil {
+			return nil, err
+		}
+		return clusterList.Msg.Clusters, nil
+	})
+}
+
+// getCluster looks up the cluster in the cached list snapshot first and only asks the API if it is not part of it.
+func getCluster(ctx context.Context, s *session.Session, project, uuid string) (*apiv1.Cluster, error) {
+	if s.Cache != nil {
+		clusters, err := listClusters(ctx, s, project)
+		if err != nil {
+			return nil, err
+		}
+		for _, cluster := range clusters {
+			if cluster.Uuid == uuid {
+				return cluster, nil
+			}
+		}
+	}
+
+	clientResponse, err := s.Client.Apiv1().Cluster().Get(ctx, connect.NewRequest(&apiv1.ClusterServiceGetRequest{
+		Uuid:    uuid,
+		Project: project,
+	}))
+	if err != nil {
+		return nil, err
+	}
+	return clientResponse.Msg.Cluster, nil
+}
+
 func computeDuration(hours int64) *durationpb.Duration {
 	return durationpb.New(time.Duration(hours) * time.Hour)
 }
@@ -42,3 +42,22 @@ type maintenanceTime struct {
 	Minute   types.Int64  `tfsdk:"minute"`
 	Timezone types.String `tfsdk:"time_zone"`
 }
+
+type clusterCostEstimateModel struct {
+	Id                  types.String             `tfsdk:"id"`
+	ClusterId           types.String             `tfsdk:"cluster_id"`
+	Project             types.String             `tfsdk:"project"`
+	Workers             []clusterCostWorkerModel `tfsdk:"workers"`
+	ControlPlaneMonthly types.Float64            `tfsdk:"control_plane_monthly"`
+	MinMonthly          types.Float64            `tfsdk:"min_monthly"`
+	MaxMonthly          types.Float64            `tfsdk:"max_monthly"`
+}
+
+type clusterCostWorkerModel struct {
+	Name        types.String  `tfsdk:"name"`
+	MachineType types.String  `tfsdk:"machine_type"`
+	Minsize     types.Int64   `tfsdk:"min_size"`
+	Maxsize     types.Int64   `tfsdk:"max_size"`
+	MinMonthly  types.Float64 `tfsdk:"min_monthly"`
+	MaxMonthly  types.Float64 `tfsdk:"max_monthly"`
+}
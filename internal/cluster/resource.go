@@ -3,6 +3,7 @@ package cluster
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"connectrpc.com/connect"
@@ -10,6 +11,8 @@ import (
 	path "github.com/hashicorp/terraform-plugin-framework/path"
 	resource "github.com/hashicorp/terraform-plugin-framework/resource"
 	schema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	types "github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	apiv1 "github.com/metal-stack-cloud/api/go/api/v1"
 	session "github.com/metal-stack-cloud/terraform-provider-metal/internal/session"
 )
@@ -18,6 +21,7 @@ var (
 	_ resource.Resource                = &ClusterResource{}
 	_ resource.ResourceWithConfigure   = &ClusterResource{}
 	_ resource.ResourceWithImportState = &ClusterResource{}
+	_ resource.ResourceWithModifyPlan  = &ClusterResource{}
 )
 
 func NewClusterResource() resource.Resource {
@@ -37,7 +41,7 @@ func (*ClusterResource) Metadata(ctx context.Context, request resource.MetadataR
 func (*ClusterResource) Schema(ctx context.Context, _ resource.SchemaRequest, response *resource.SchemaResponse) {
 	response.Schema = schema.Schema{
 		Attributes:          clusterResourceAttributes(),
-		MarkdownDescription: "Managing Clusters of worker nodes. Required permissions: `Cluster *`. Can be imported by ID or name.",
+		MarkdownDescription: "Managing Clusters of worker nodes. Partition and machine types are validated against the assets while planning, and changed worker groups show the change of the estimated monthly costs of the control plane and the worker machines. Required permissions: `Cluster *`, `Asset List`, `Payment GetDefaultPrices`. Can be imported by ID or name.",
 	}
 }
 
@@ -117,32 +121,31 @@ func (c *ClusterResource) Read(ctx context.Context, request resource.ReadRequest
 		return
 	}
 
-	requestMessage := apiv1.ClusterServiceGetRequest{
-		Uuid:    state.Uuid.ValueString(),
-		Project: state.Project.ValueString(),
-	}
+	project := state.Project.ValueString()
 
 	// check if project is set
-	if requestMessage.Project == "" {
-		requestMessage.Project = c.session.Project
+	if project == "" {
+		project = c.session.Project
 	}
 
-	clientResponse, err := c.session.Client.Apiv1().Cluster().Get(ctx, connect.NewRequest(&requestMessage))
-
+	cluster, err := getCluster(ctx, c.session, project, state.Uuid.ValueString())
 	if err != nil {
 		response.Diagnostics.AddError("failed to get cluster", err.Error())
 		return
 	}
 
+	// the cluster may be shared with other readers through the list cache, so it must not be modified
+	model := clusterResponseMapping(cluster)
+
 	// Apply Kubernetes patch version if necessary
-	applyPatch := patchKubernetesVersion(clientResponse.Msg.Cluster.Kubernetes.Version, state.Kubernetes.ValueString())
+	applyPatch := patchKubernetesVersion(cluster.Kubernetes.Version, state.Kubernetes.ValueString())
 	if applyPatch {
-		response.Diagnostics.AddAttributeWarning(path.Root("kubernetes"), "Upgraded Kubernetes version", fmt.Sprintf("We upgraded your Kubernetes version to the latest supported patch version: %v", clientResponse.Msg.Cluster.Kubernetes.Version))
-		clientResponse.Msg.Cluster.Kubernetes.Version = state.Kubernetes.ValueString()
+		response.Diagnostics.AddAttributeWarning(path.Root("kubernetes"), "Upgraded Kubernetes version", fmt.Sprintf("We upgraded your Kubernetes version to the latest supported patch version: %v", cluster.Kubernetes.Version))
+		model.Kubernetes = state.Kubernetes
 	}
 
 	// Save updated data into Terraform state
-	data := response.State.Set(ctx, clusterResponseMapping(clientResponse.Msg.Cluster))
+	data := response.State.Set(ctx, model)
 	response.Diagnostics.Append(data...)
 }
 
@@ -227,6 +230,104 @@ func (c *ClusterResource) Delete(ctx context.Context, request resource.DeleteReq
 	}
 }
 
+// ModifyPlan implements resource.ResourceWithModifyPlan.
+func (c *ClusterResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	c.session.CheckPlan(ctx, req, resp)
+	// the assets and prices cannot be looked up as long as the provider is not configured
+	if resp.Diagnostics.HasError() || req.Plan.Raw.IsNull() || c.session == nil {
+		return
+	}
+	c.checkAssets(ctx, req, resp)
+	if resp.Diagnostics.HasError() || req.State.Raw.IsNull() {
+		return
+	}
+	c.warnCostDelta(ctx, req, resp)
+}
+
+// checkAssets validates the partition and machine types of a new cluster, or those changed by the plan, against the asset catalogue,
+// which would otherwise only fail during creation. The plan is not blocked if the assets cannot be listed.
+func (c *ClusterResource) checkAssets(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var partition types.String
+	var workers []clusterWorkerModel
+	if diags := req.Plan.GetAttribute(ctx, path.Root("partition"), &partition); diags.HasError() {
+		return
+	}
+	if diags := req.Plan.GetAttribute(ctx, path.Root("workers"), &workers); diags.HasError() {
+		return
+	}
+	checkPartition := true
+	var checkWorkers []bool
+	if !req.State.Raw.IsNull() {
+		var statePartition types.String
+		var stateWorkers []clusterWorkerModel
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("partition"), &statePartition)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("workers"), &stateWorkers)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		// an existing cluster stays valid, e.g. if its partition stops accepting new clusters
+		checkPartition = !partition.Equal(statePartition)
+		if !checkPartition {
+			checkWorkers = changedMachineTypes(workers, stateWorkers)
+			if !slices.Contains(checkWorkers, true) {
+				return
+			}
+		}
+	}
+
+	assetResp, err := c.session.Client.Apiv1().Asset().List(ctx, connect.NewRequest(&apiv1.AssetServiceListRequest{}))
+	if err != nil {
+		tflog.Debug(ctx, "skipping cluster asset validation", map[string]any{"error": err.Error()})
+		return
+	}
+	resp.Diagnostics.Append(checkClusterAssets(assetResp.Msg.Assets, partition, checkPartition, workers, checkWorkers)...)
+}
+
+// warnCostDelta adds a warning with the change of the estimated monthly costs if the plan resizes worker groups or changes their machine types.
+// The plan is never blocked by missing prices.
+func (c *ClusterResource) warnCostDelta(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var stateWorkers, planWorkers []clusterWorkerModel
+	if diags := req.State.GetAttribute(ctx, path.Root("workers"), &stateWorkers); diags.HasError() {
+		return
+	}
+	if diags := req.Plan.GetAttribute(ctx, path.Root("workers"), &planWorkers); diags.HasError() {
+		return
+	}
+	for _, w := range planWorkers {
+		if w.MachineType.IsUnknown() || w.Minsize.IsUnknown() || w.Maxsize.IsUnknown() {
+			return
+		}
+	}
+	before, after := costWorkersFromCluster(stateWorkers), costWorkersFromCluster(planWorkers)
+	if costEstimateId(before) == costEstimateId(after) {
+		return
+	}
+
+	prices, err := getClusterPrices(ctx, c.session)
+	if err != nil {
+		tflog.Debug(ctx, "skipping cluster cost estimate", map[string]any{"error": err.Error()})
+		return
+	}
+	beforeMin, beforeMax, err := prices.estimate(before)
+	if err != nil {
+		tflog.Debug(ctx, "skipping cluster cost estimate", map[string]any{"error": err.Error()})
+		return
+	}
+	afterMin, afterMax, err := prices.estimate(after)
+	if err != nil {
+		tflog.Debug(ctx, "skipping cluster cost estimate", map[string]any{"error": err.Error()})
+		return
+	}
+
+	resp.Diagnostics.AddAttributeWarning(
+		path.Root("workers"),
+		"Estimated cluster costs change",
+		fmt.Sprintf("The estimated monthly costs of the cluster change from %.2f - %.2f to %.2f - %.2f (%+.2f / %+.2f). "+
+			"See the metal_cluster_cost_estimate data source for details.",
+			beforeMin, beforeMax, afterMin, afterMax, roundCents(afterMin-beforeMin), roundCents(afterMax-beforeMax)),
+	)
+}
+
 // ImportState implements resource.ResourceWithImportState.
 func (c *ClusterResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	if _, err := uuid.ParseUUID(req.ID); err == nil {
@@ -235,16 +336,12 @@ func (c *ClusterResource) ImportState(ctx context.Context, req resource.ImportSt
 	}
 
 	name := req.ID
-	listRequestMessage := &apiv1.ClusterServiceListRequest{
-		Project: c.session.Project,
-	}
-	clusterList, err := c.session.Client.Apiv1().Cluster().List(ctx, connect.NewRequest(listRequestMessage))
+	list, err := listClusters(ctx, c.session, c.session.Project)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to get cluster list", err.Error())
 		return
 	}
 	// find uuid and set uuidString
-	list := clusterList.Msg.Clusters
 	uuidStr, err := findUuidByName(list, name)
 	if err != nil {
 		resp.Diagnostics.AddError(fmt.Sprintf("Failed to find cluster with name %v", req.ID), err.Error())
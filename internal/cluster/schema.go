@@ -5,6 +5,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	datasourceschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -50,7 +51,7 @@ func clusterResourceAttributes() map[string]resourceschema.Attribute {
 		"tenant": resourceschema.StringAttribute{
 			Computed:    true,
 			Optional:    true,
-			Description: "Tenant ID",
+			Description: "The login of the tenant (organization) owning the cluster, see the `metal_tenant` data source.",
 			PlanModifiers: []planmodifier.String{
 				stringplanmodifier.UseStateForUnknown(),
 				stringplanmodifier.RequiresReplace(),
@@ -283,3 +284,72 @@ func clusterDataSourceAttributes() map[string]datasourceschema.Attribute {
 		},
 	}
 }
+
+func clusterCostEstimateDataSourceAttributes() map[string]datasourceschema.Attribute {
+	return map[string]datasourceschema.Attribute{
+		"id": datasourceschema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "A hash of the estimated worker groups.",
+		},
+		"cluster_id": datasourceschema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Estimate the costs of the worker groups of an existing cluster. Conflicts with `workers`.",
+			Validators: []validator.String{
+				stringvalidator.ExactlyOneOf(path.MatchRoot("cluster_id"), path.MatchRoot("workers")),
+			},
+		},
+		"project": datasourceschema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "The project of the cluster given by `cluster_id`. Defaults to the provider project.",
+		},
+		"workers": datasourceschema.ListNestedAttribute{
+			Optional:            true,
+			Computed:            true,
+			MarkdownDescription: "The worker groups to estimate. Filled from the cluster if `cluster_id` is set.",
+			NestedObject: datasourceschema.NestedAttributeObject{
+				Attributes: map[string]datasourceschema.Attribute{
+					"name": datasourceschema.StringAttribute{
+						Optional:            true,
+						Computed:            true,
+						MarkdownDescription: "The group name of the worker nodes",
+					},
+					"machine_type": datasourceschema.StringAttribute{
+						Optional:            true,
+						Computed:            true,
+						MarkdownDescription: "The machine type for this worker group, see the `metal_assets` data source.",
+					},
+					"min_size": datasourceschema.Int64Attribute{
+						Optional:            true,
+						Computed:            true,
+						MarkdownDescription: "The minimum count of available nodes with type machinetype",
+					},
+					"max_size": datasourceschema.Int64Attribute{
+						Optional:            true,
+						Computed:            true,
+						MarkdownDescription: "The maximum count of available nodes with type machinetype for autoscaling",
+					},
+					"min_monthly": datasourceschema.Float64Attribute{
+						Computed:            true,
+						MarkdownDescription: "The monthly costs of this worker group at its minimum size.",
+					},
+					"max_monthly": datasourceschema.Float64Attribute{
+						Computed:            true,
+						MarkdownDescription: "The monthly costs of this worker group at its maximum size.",
+					},
+				},
+			},
+		},
+		"control_plane_monthly": datasourceschema.Float64Attribute{
+			Computed:            true,
+			MarkdownDescription: "The monthly costs of the Kubernetes control plane.",
+		},
+		"min_monthly": datasourceschema.Float64Attribute{
+			Computed:            true,
+			MarkdownDescription: "The monthly costs of the cluster with all worker groups at their minimum size.",
+		},
+		"max_monthly": datasourceschema.Float64Attribute{
+			Computed:            true,
+			MarkdownDescription: "The monthly costs of the cluster with all worker groups scaled to their maximum size.",
+		},
+	}
+}
@@ -0,0 +1,35 @@
+package currentuser_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/metal-stack-cloud/terraform-provider-metal/internal/provider"
+)
+
+var (
+	testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+		"metal": providerserver.NewProtocol6WithError(provider.New("test")()),
+	}
+)
+
+func TestAccCurrentUserDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCurrentUserDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.metal_current_user.me", "id"),
+					resource.TestCheckResourceAttrSet("data.metal_current_user.me", "default_project"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCurrentUserDataSourceConfig = `
+data "metal_current_user" "me" {}
+`
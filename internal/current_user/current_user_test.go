@@ -0,0 +1,114 @@
+package currentuser
+
+import (
+	"errors"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	apiv1 "github.com/metal-stack-cloud/api/go/api/v1"
+	assert "github.com/stretchr/testify/assert"
+)
+
+func Test_currentUserFromApi(t *testing.T) {
+	user := &apiv1.User{
+		Login: "octocat@github",
+		Name:  "Mona Lisa Octocat",
+		Email: "octocat@github.example",
+		Tenants: []*apiv1.Tenant{
+			{Login: "octocat@github"},
+			{Login: "acme@github"},
+		},
+		Projects: []*apiv1.Project{
+			{Uuid: "5a6b7c8d-1e2f-4a3b-9c4d-5e6f7a8b9c33", Tenant: "acme@github", IsDefaultProject: true},
+			{Uuid: "7c8f1d2e-3a4b-4c5d-8e6f-9a0b1c2d3e4f", Tenant: "octocat@github", IsDefaultProject: true},
+		},
+		DefaultTenant: &apiv1.Tenant{
+			Login:         "octocat@github",
+			OauthProvider: apiv1.OAuthProvider_O_AUTH_PROVIDER_GITHUB,
+		},
+	}
+	want := currentUserModel{
+		Id:            basetypes.NewStringValue("octocat@github"),
+		Name:          basetypes.NewStringValue("Mona Lisa Octocat"),
+		Email:         basetypes.NewStringValue("octocat@github.example"),
+		OauthProvider: basetypes.NewStringValue("github"),
+		MachineToken:  basetypes.NewBoolValue(false),
+		TokenId:       basetypes.NewStringValue("a1b2c3d4-e5f6-4a7b-8c9d-0e1f2a3b4c5d"),
+		Tenants: []basetypes.StringValue{
+			basetypes.NewStringValue("octocat@github"),
+			basetypes.NewStringValue("acme@github"),
+		},
+		DefaultTenant:  basetypes.NewStringValue("octocat@github"),
+		DefaultProject: basetypes.NewStringValue("7c8f1d2e-3a4b-4c5d-8e6f-9a0b1c2d3e4f"),
+	}
+
+	got := currentUserFromApi(user, &jwt.RegisteredClaims{ID: "a1b2c3d4-e5f6-4a7b-8c9d-0e1f2a3b4c5d"})
+	assert.Equal(t, want, got)
+}
+
+func Test_currentUserFromClaims(t *testing.T) {
+	claims := &jwt.RegisteredClaims{
+		Subject: "octocat@github",
+		ID:      "a1b2c3d4-e5f6-4a7b-8c9d-0e1f2a3b4c5d",
+	}
+	scope := &apiv1.MethodServiceTokenScopedListResponse{
+		TenantRoles: map[string]apiv1.TenantRole{
+			"acme@github": apiv1.TenantRole_TENANT_ROLE_VIEWER,
+		},
+	}
+	want := currentUserModel{
+		Id:             basetypes.NewStringValue("octocat@github"),
+		Name:           basetypes.NewStringValue(""),
+		Email:          basetypes.NewStringValue(""),
+		OauthProvider:  basetypes.NewStringValue("github"),
+		MachineToken:   basetypes.NewBoolValue(true),
+		TokenId:        basetypes.NewStringValue("a1b2c3d4-e5f6-4a7b-8c9d-0e1f2a3b4c5d"),
+		Tenants:        []basetypes.StringValue{basetypes.NewStringValue("acme@github")},
+		DefaultTenant:  basetypes.NewStringValue("acme@github"),
+		DefaultProject: basetypes.NewStringValue("5a6b7c8d-1e2f-4a3b-9c4d-5e6f7a8b9c33"),
+	}
+
+	got := currentUserFromClaims(claims, scope, "5a6b7c8d-1e2f-4a3b-9c4d-5e6f7a8b9c33")
+	assert.Equal(t, want, got)
+}
+
+func Test_deniedMachineToken(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		machineToken bool
+		want         bool
+	}{
+		{
+			name:         "machine token denied",
+			err:          connect.NewError(connect.CodePermissionDenied, errors.New("not a user")),
+			machineToken: true,
+			want:         true,
+		},
+		{
+			name:         "machine token unauthenticated",
+			err:          connect.NewError(connect.CodeUnauthenticated, errors.New("not a user")),
+			machineToken: true,
+			want:         true,
+		},
+		{
+			name:         "machine token unavailable",
+			err:          connect.NewError(connect.CodeUnavailable, errors.New("try again")),
+			machineToken: true,
+			want:         false,
+		},
+		{
+			name:         "user token unauthenticated",
+			err:          connect.NewError(connect.CodeUnauthenticated, errors.New("token expired")),
+			machineToken: false,
+			want:         false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, deniedMachineToken(tt.err, tt.machineToken))
+		})
+	}
+}
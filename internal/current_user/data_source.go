@@ -0,0 +1,132 @@
+package currentuser
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	apiv1 "github.com/metal-stack-cloud/api/go/api/v1"
+	"github.com/metal-stack-cloud/terraform-provider-metal/internal/session"
+)
+
+var (
+	_ datasource.DataSource              = &CurrentUserDataSource{}
+	_ datasource.DataSourceWithConfigure = &CurrentUserDataSource{}
+)
+
+func NewCurrentUserDataSource() datasource.DataSource {
+	return &CurrentUserDataSource{}
+}
+
+// CurrentUserDataSource defines the data source implementation.
+type CurrentUserDataSource struct {
+	session *session.Session
+}
+
+// Metadata implements datasource.DataSource.
+func (*CurrentUserDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_current_user"
+}
+
+// Schema implements datasource.DataSource.
+func (*CurrentUserDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Shows who is authenticated by the api token of the provider, e.g. to tag resources with who applied them. \n" +
+			"Machine tokens are not users, for them the issuing user is taken from the token and the tenants from its roles. \n" +
+			"Required permissions: `User Get` for user tokens.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The login of the user, e.g. `octocat@github`. For machine tokens the login of the user who issued the token.",
+			},
+			"name": schema.StringAttribute{
+				Computed:    true,
+				Description: "The name of the user. Empty for machine tokens.",
+			},
+			"email": schema.StringAttribute{
+				Computed:    true,
+				Description: "The email address of the user. Empty for machine tokens.",
+			},
+			"oauth_provider": schema.StringAttribute{
+				Computed:    true,
+				Description: "The provider the user logs in with, e.g. 'github', 'azure' or 'google'.",
+			},
+			"machine_token": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Indicates that the provider authenticates with a machine token instead of a user token.",
+			},
+			"token_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the api token.",
+			},
+			"tenants": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The tenants (organizations) the user is a member of. For machine tokens the tenants the token has a role in.",
+			},
+			"default_tenant": schema.StringAttribute{
+				Computed:    true,
+				Description: "The personal tenant of the user. For machine tokens the only tenant the token has a role in, if any.",
+			},
+			"default_project": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the default project of the user. For machine tokens the provider project.",
+			},
+		},
+	}
+}
+
+// Configure implements datasource.DataSourceWithConfigure.
+func (u *CurrentUserDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	session, ok := req.ProviderData.(*session.Session)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *session.Session, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	u.session = session
+}
+
+// Read implements datasource.DataSource.
+func (u *CurrentUserDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	userResp, err := u.session.Client.Apiv1().User().Get(ctx, connect.NewRequest(&apiv1.UserServiceGetRequest{}))
+	if err == nil {
+		data := currentUserFromApi(userResp.Msg.User, u.session.TokenClaims)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+	if !deniedMachineToken(err, u.session.IsMachineToken()) {
+		resp.Diagnostics.AddError("Unable to get the current user", err.Error())
+		return
+	}
+	tflog.Debug(ctx, "user service denied the machine token, describing it by its claims", map[string]any{"error": err.Error()})
+
+	scopeResp, err := u.session.Client.Apiv1().Method().TokenScopedList(ctx, connect.NewRequest(&apiv1.MethodServiceTokenScopedListRequest{}))
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read the token scope", err.Error())
+		return
+	}
+	data := currentUserFromClaims(u.session.TokenClaims, scopeResp.Msg, u.session.Project)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// deniedMachineToken returns true if the user service rejected a machine token, which is no user.
+// Any other error, e.g. an expired user token, is a real failure.
+func deniedMachineToken(err error, machineToken bool) bool {
+	if !machineToken {
+		return false
+	}
+	code := connect.CodeOf(err)
+	return code == connect.CodePermissionDenied || code == connect.CodeUnauthenticated
+}
@@ -0,0 +1,93 @@
+package currentuser
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	apiv1 "github.com/metal-stack-cloud/api/go/api/v1"
+	"github.com/metal-stack-cloud/terraform-provider-metal/internal/shared"
+)
+
+type currentUserModel struct {
+	Id             types.String   `tfsdk:"id"`
+	Name           types.String   `tfsdk:"name"`
+	Email          types.String   `tfsdk:"email"`
+	OauthProvider  types.String   `tfsdk:"oauth_provider"`
+	MachineToken   types.Bool     `tfsdk:"machine_token"`
+	TokenId        types.String   `tfsdk:"token_id"`
+	Tenants        []types.String `tfsdk:"tenants"`
+	DefaultTenant  types.String   `tfsdk:"default_tenant"`
+	DefaultProject types.String   `tfsdk:"default_project"`
+}
+
+func currentUserFromApi(u *apiv1.User, claims *jwt.RegisteredClaims) currentUserModel {
+	model := currentUserModel{
+		Id:             types.StringValue(u.Login),
+		Name:           types.StringValue(u.Name),
+		Email:          types.StringValue(u.Email),
+		OauthProvider:  types.StringValue("unspecified"),
+		MachineToken:   types.BoolValue(false),
+		TokenId:        types.StringValue(tokenId(claims)),
+		Tenants:        make([]types.String, len(u.Tenants)),
+		DefaultTenant:  types.StringValue(""),
+		DefaultProject: types.StringValue(""),
+	}
+	for i, tenant := range u.Tenants {
+		model.Tenants[i] = types.StringValue(tenant.Login)
+	}
+	if u.DefaultTenant != nil {
+		model.DefaultTenant = types.StringValue(u.DefaultTenant.Login)
+		model.OauthProvider = types.StringValue(shared.OAuthProviderToString(u.DefaultTenant.OauthProvider))
+		for _, project := range u.Projects {
+			if project.IsDefaultProject && project.Tenant == u.DefaultTenant.Login {
+				model.DefaultProject = types.StringValue(project.Uuid)
+				break
+			}
+		}
+	}
+	return model
+}
+
+// currentUserFromClaims describes a machine token, which is not a user, by the claims of the token and the tenants it has a role in.
+func currentUserFromClaims(claims *jwt.RegisteredClaims, scope *apiv1.MethodServiceTokenScopedListResponse, project string) currentUserModel {
+	tenants := make([]string, 0, len(scope.GetTenantRoles()))
+	for tenant := range scope.GetTenantRoles() {
+		tenants = append(tenants, tenant)
+	}
+	slices.Sort(tenants)
+
+	model := currentUserModel{
+		Id:             types.StringValue(""),
+		Name:           types.StringValue(""),
+		Email:          types.StringValue(""),
+		OauthProvider:  types.StringValue("unspecified"),
+		MachineToken:   types.BoolValue(true),
+		TokenId:        types.StringValue(tokenId(claims)),
+		Tenants:        make([]types.String, len(tenants)),
+		DefaultTenant:  types.StringValue(""),
+		DefaultProject: types.StringValue(project),
+	}
+	if claims != nil {
+		model.Id = types.StringValue(claims.Subject)
+		// the subject of a token is the login of the user who issued it, e.g. octocat@github
+		if _, provider, found := strings.Cut(claims.Subject, "@"); found {
+			model.OauthProvider = types.StringValue(provider)
+		}
+	}
+	for i, tenant := range tenants {
+		model.Tenants[i] = types.StringValue(tenant)
+	}
+	if len(tenants) == 1 {
+		model.DefaultTenant = model.Tenants[0]
+	}
+	return model
+}
+
+func tokenId(claims *jwt.RegisteredClaims) string {
+	if claims == nil {
+		return ""
+	}
+	return claims.ID
+}
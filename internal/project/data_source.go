@@ -0,0 +1,91 @@
+package project
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	apiv1 "github.com/metal-stack-cloud/api/go/api/v1"
+	"github.com/metal-stack-cloud/terraform-provider-metal/internal/session"
+)
+
+var (
+	_ datasource.DataSource              = &ProjectDataSource{}
+	_ datasource.DataSourceWithConfigure = &ProjectDataSource{}
+)
+
+func NewProjectDataSource() datasource.DataSource {
+	return &ProjectDataSource{}
+}
+
+// ProjectDataSource defines the data source implementation.
+type ProjectDataSource struct {
+	session *session.Session
+}
+
+// Metadata implements datasource.DataSource.
+func (*ProjectDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project"
+}
+
+// Schema implements datasource.DataSource.
+func (*ProjectDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up a project by ID or name. \n" +
+			"Required permissions: `Project Get`, `Project List` when looking up by name.",
+		Attributes: projectDataSourceAttributes(),
+	}
+}
+
+// Configure implements datasource.DataSourceWithConfigure.
+func (p *ProjectDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	session, ok := req.ProviderData.(*session.Session)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *session.Session, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	p.session = session
+}
+
+// Read implements datasource.DataSource.
+func (p *ProjectDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data projectModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectId := data.Uuid.ValueString()
+	if projectId == "" {
+		list, err := listProjects(ctx, p.session, "")
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to get project list", err.Error())
+			return
+		}
+		found, err := findProjectByName(list, data.Name.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Failed to find project with name %v", data.Name.ValueString()), err.Error())
+			return
+		}
+		projectId = found.Uuid
+	}
+
+	projectResp, err := p.session.Client.Apiv1().Project().Get(ctx, connect.NewRequest(&apiv1.ProjectServiceGetRequest{
+		Project: projectId,
+	}))
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get project", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, projectFromApi(projectResp.Msg.Project))...)
+}
@@ -0,0 +1,57 @@
+package project
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	apiv1 "github.com/metal-stack-cloud/api/go/api/v1"
+	"github.com/metal-stack-cloud/terraform-provider-metal/internal/session"
+)
+
+// listProjects returns all projects the api token has access to, optionally restricted to a tenant.
+func listProjects(ctx context.Context, s *session.Session, tenant string) ([]*apiv1.Project, error) {
+	listReq := &apiv1.ProjectServiceListRequest{}
+	if tenant != "" {
+		listReq.Tenant = &tenant
+	}
+	projectResp, err := s.Client.Apiv1().Project().List(ctx, connect.NewRequest(listReq))
+	if err != nil {
+		return nil, err
+	}
+	return projectResp.Msg.GetProjects(), nil
+}
+
+// findProjectByName returns the project with the given name, names are not unique across tenants.
+func findProjectByName(projects []*apiv1.Project, name string) (*apiv1.Project, error) {
+	var found *apiv1.Project
+	for _, project := range projects {
+		if project.Name != name {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("the project name %q is ambiguous, use the project ID instead", name)
+		}
+		found = project
+	}
+	if found == nil {
+		return nil, fmt.Errorf("no project named %q found", name)
+	}
+	return found, nil
+}
+
+// getProjectMember returns the member of the project, or nil if the user is no member.
+func getProjectMember(ctx context.Context, s *session.Session, project, memberId string) (*apiv1.ProjectMember, error) {
+	projectResp, err := s.Client.Apiv1().Project().Get(ctx, connect.NewRequest(&apiv1.ProjectServiceGetRequest{
+		Project: project,
+	}))
+	if err != nil {
+		return nil, err
+	}
+	for _, member := range projectResp.Msg.ProjectMembers {
+		if member.Id == memberId {
+			return member, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,158 @@
+package project
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	apiv1 "github.com/metal-stack-cloud/api/go/api/v1"
+	"github.com/metal-stack-cloud/terraform-provider-metal/internal/session"
+	"github.com/metal-stack-cloud/terraform-provider-metal/internal/shared"
+)
+
+var (
+	_ resource.Resource                = &ProjectInviteResource{}
+	_ resource.ResourceWithConfigure   = &ProjectInviteResource{}
+	_ resource.ResourceWithImportState = &ProjectInviteResource{}
+	_ resource.ResourceWithModifyPlan  = &ProjectInviteResource{}
+)
+
+func NewProjectInviteResource() resource.Resource {
+	return &ProjectInviteResource{}
+}
+
+type ProjectInviteResource struct {
+	session *session.Session
+}
+
+// Metadata implements resource.Resource.
+func (*ProjectInviteResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project_invite"
+}
+
+// Schema implements resource.Resource.
+func (*ProjectInviteResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: projectInviteResourceAttributes(),
+		MarkdownDescription: "Invites a user to a project. Pass the `join_link` to the user, who joins with the given role on opening it. \n" +
+			"Manage the role of the user afterwards with a `metal_project_member`. \n" +
+			"The invite can be imported by its secret as long as it has not been accepted. \n" +
+			"Required permissions: `Project Invite`, `Project InviteGet`, `Project InviteDelete`.",
+	}
+}
+
+// Configure implements resource.ResourceWithConfigure.
+func (i *ProjectInviteResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	session, ok := req.ProviderData.(*session.Session)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *session.Session, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	i.session = session
+}
+
+// Create implements resource.Resource.
+func (i *ProjectInviteResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan projectInviteModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	project := plan.Project.ValueString()
+	if project == "" {
+		project = i.session.Project
+	}
+	role, err := shared.ProjectRoleFromString(plan.Role.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid project role", err.Error())
+		return
+	}
+
+	inviteResp, err := i.session.Client.Apiv1().Project().Invite(ctx, connect.NewRequest(&apiv1.ProjectServiceInviteRequest{
+		Project: project,
+		Role:    role,
+	}))
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create project invite", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, projectInviteFromApi(inviteResp.Msg.Invite, i.session.ConsoleUrl))...)
+}
+
+// Read implements resource.Resource.
+func (i *ProjectInviteResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state projectInviteModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	inviteResp, err := i.session.Client.Apiv1().Project().InviteGet(ctx, connect.NewRequest(&apiv1.ProjectServiceInviteGetRequest{
+		Secret: state.Secret.ValueString(),
+	}))
+	if connect.CodeOf(err) == connect.CodeNotFound {
+		if state.Project.IsNull() {
+			resp.Diagnostics.AddError("Project invite not found", "Only pending invites can be imported.")
+			return
+		}
+		// the invite was accepted or has expired, keep it so it is not sent again
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get project invite", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, projectInviteFromApi(inviteResp.Msg.Invite, i.session.ConsoleUrl))...)
+}
+
+// Update implements resource.Resource.
+func (i *ProjectInviteResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// all configurable attributes require a replacement, the invite itself cannot change
+	var state projectInviteModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Delete implements resource.Resource.
+func (i *ProjectInviteResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state projectInviteModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if state.Joined.ValueBool() {
+		// an accepted invite is gone, the membership has to be removed with metal_project_member
+		return
+	}
+
+	_, err := i.session.Client.Apiv1().Project().InviteDelete(ctx, connect.NewRequest(&apiv1.ProjectServiceInviteDeleteRequest{
+		Project: state.Project.ValueString(),
+		Secret:  state.Secret.ValueString(),
+	}))
+	if err != nil && connect.CodeOf(err) != connect.CodeNotFound {
+		resp.Diagnostics.AddError("Failed to delete project invite", err.Error())
+		return
+	}
+}
+
+// ModifyPlan implements resource.ResourceWithModifyPlan.
+func (i *ProjectInviteResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	i.session.CheckPlan(ctx, req, resp)
+}
+
+// ImportState implements resource.ResourceWithImportState.
+func (i *ProjectInviteResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("secret"), req, resp)
+}
@@ -0,0 +1,102 @@
+package project
+
+import (
+	"context"
+	"crypto/sha1"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/metal-stack-cloud/terraform-provider-metal/internal/session"
+)
+
+var (
+	_ datasource.DataSource              = &ProjectListDataSource{}
+	_ datasource.DataSourceWithConfigure = &ProjectListDataSource{}
+)
+
+func NewProjectListDataSource() datasource.DataSource {
+	return &ProjectListDataSource{}
+}
+
+// ProjectListDataSource defines the data source implementation.
+type ProjectListDataSource struct {
+	session *session.Session
+}
+
+// Metadata implements datasource.DataSource.
+func (*ProjectListDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_projects"
+}
+
+// Schema implements datasource.DataSource.
+func (*ProjectListDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists all projects the api token has access to. \n" +
+			"Required permissions: `Project List`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"tenant": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list the projects of this tenant (organization).",
+			},
+			"items": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "All projects",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: projectListDataSourceAttributes(),
+				},
+			},
+		},
+	}
+}
+
+// Configure implements datasource.DataSourceWithConfigure.
+func (p *ProjectListDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	session, ok := req.ProviderData.(*session.Session)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *session.Session, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	p.session = session
+}
+
+// Read implements datasource.DataSource.
+func (p *ProjectListDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ProjectListDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projects, err := listProjects(ctx, p.session, data.Tenant.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read projects", err.Error())
+		return
+	}
+	tflog.Trace(ctx, "read projects")
+
+	data.Items = make([]projectModel, 0, len(projects))
+	ids := make([]string, 0, len(projects))
+	for _, project := range projects {
+		data.Items = append(data.Items, projectFromApi(project))
+		ids = append(ids, project.Uuid)
+	}
+
+	dataId := fmt.Sprintf("%x", sha1.Sum([]byte(strings.Join(ids, ""))))
+	data.ContentId = types.StringValue(dataId)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
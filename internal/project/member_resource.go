@@ -0,0 +1,196 @@
+package project
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	apiv1 "github.com/metal-stack-cloud/api/go/api/v1"
+	"github.com/metal-stack-cloud/terraform-provider-metal/internal/session"
+	"github.com/metal-stack-cloud/terraform-provider-metal/internal/shared"
+)
+
+var (
+	_ resource.Resource                = &ProjectMemberResource{}
+	_ resource.ResourceWithConfigure   = &ProjectMemberResource{}
+	_ resource.ResourceWithImportState = &ProjectMemberResource{}
+	_ resource.ResourceWithModifyPlan  = &ProjectMemberResource{}
+)
+
+func NewProjectMemberResource() resource.Resource {
+	return &ProjectMemberResource{}
+}
+
+type ProjectMemberResource struct {
+	session *session.Session
+}
+
+// Metadata implements resource.Resource.
+func (*ProjectMemberResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project_member"
+}
+
+// Schema implements resource.Resource.
+func (*ProjectMemberResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: projectMemberResourceAttributes(),
+		MarkdownDescription: "Binds a role to a member of a project. \n" +
+			"Users can only join a project by accepting an invite, so the user must be a member already. " +
+			"Destroying the resource removes the user from the project. \n" +
+			"Required permissions: `Project Get`, `Project UpdateMember`, `Project RemoveMember`. " +
+			"Can be imported by `project/member_id`, or by `member_id` for the provider project.",
+	}
+}
+
+// Configure implements resource.ResourceWithConfigure.
+func (m *ProjectMemberResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	session, ok := req.ProviderData.(*session.Session)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *session.Session, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	m.session = session
+}
+
+// Create implements resource.Resource.
+func (m *ProjectMemberResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan projectMemberModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	project := plan.Project.ValueString()
+	if project == "" {
+		project = m.session.Project
+	}
+
+	member, err := getProjectMember(ctx, m.session, project, plan.MemberId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get project members", err.Error())
+		return
+	}
+	if member == nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("member_id"),
+			"User is no project member",
+			fmt.Sprintf("The user %q has not joined the project %q. Invite the user with a metal_project_invite first.", plan.MemberId.ValueString(), project),
+		)
+		return
+	}
+
+	if shared.ProjectRoleToString(member.Role) != plan.Role.ValueString() {
+		member, err = m.updateRole(ctx, project, member.Id, plan.Role.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to update project member", err.Error())
+			return
+		}
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, projectMemberFromApi(project, member))...)
+}
+
+// Read implements resource.Resource.
+func (m *ProjectMemberResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state projectMemberModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	member, err := getProjectMember(ctx, m.session, state.Project.ValueString(), state.MemberId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get project members", err.Error())
+		return
+	}
+	if member == nil {
+		// the user left or was removed outside of terraform
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, projectMemberFromApi(state.Project.ValueString(), member))...)
+}
+
+// Update implements resource.Resource.
+func (m *ProjectMemberResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state projectMemberModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	var plan projectMemberModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	member, err := m.updateRole(ctx, state.Project.ValueString(), state.MemberId.ValueString(), plan.Role.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to update project member", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, projectMemberFromApi(state.Project.ValueString(), member))...)
+}
+
+// Delete implements resource.Resource.
+func (m *ProjectMemberResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state projectMemberModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := m.session.Client.Apiv1().Project().RemoveMember(ctx, connect.NewRequest(&apiv1.ProjectServiceRemoveMemberRequest{
+		Project:  state.Project.ValueString(),
+		MemberId: state.MemberId.ValueString(),
+	}))
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to remove project member", err.Error())
+		return
+	}
+}
+
+// ModifyPlan implements resource.ResourceWithModifyPlan.
+func (m *ProjectMemberResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	m.session.CheckPlan(ctx, req, resp)
+}
+
+// ImportState implements resource.ResourceWithImportState.
+func (m *ProjectMemberResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	project, memberId, found := strings.Cut(req.ID, "/")
+	if !found {
+		project, memberId = m.session.Project, req.ID
+	}
+	if project == "" || memberId == "" {
+		resp.Diagnostics.AddError("Invalid import ID", fmt.Sprintf("expected project/member_id or member_id, got %q", req.ID))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), projectMemberId(project, memberId))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project"), project)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("member_id"), memberId)...)
+}
+
+func (m *ProjectMemberResource) updateRole(ctx context.Context, project, memberId, role string) (*apiv1.ProjectMember, error) {
+	projectRole, err := shared.ProjectRoleFromString(role)
+	if err != nil {
+		return nil, err
+	}
+	updateResp, err := m.session.Client.Apiv1().Project().UpdateMember(ctx, connect.NewRequest(&apiv1.ProjectServiceUpdateMemberRequest{
+		Project:  project,
+		MemberId: memberId,
+		Role:     projectRole,
+	}))
+	if err != nil {
+		return nil, err
+	}
+	return updateResp.Msg.ProjectMember, nil
+}
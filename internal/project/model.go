@@ -0,0 +1,239 @@
+package project
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	apiv1 "github.com/metal-stack-cloud/api/go/api/v1"
+	"github.com/metal-stack-cloud/terraform-provider-metal/internal/shared"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type projectModel struct {
+	Uuid           types.String `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	Description    types.String `tfsdk:"description"`
+	Tenant         types.String `tfsdk:"tenant"`
+	DefaultProject types.Bool   `tfsdk:"default_project"`
+	CreatedAt      types.String `tfsdk:"created_at"`
+	UpdatedAt      types.String `tfsdk:"updated_at"`
+}
+
+// ProjectListDataSourceModel describes the data source data model.
+type ProjectListDataSourceModel struct {
+	ContentId types.String   `tfsdk:"id"`
+	Tenant    types.String   `tfsdk:"tenant"`
+	Items     []projectModel `tfsdk:"items"`
+}
+
+func projectFromApi(p *apiv1.Project) projectModel {
+	return projectModel{
+		Uuid:           types.StringValue(p.Uuid),
+		Name:           types.StringValue(p.Name),
+		Description:    types.StringValue(p.Description),
+		Tenant:         types.StringValue(p.Tenant),
+		DefaultProject: types.BoolValue(p.IsDefaultProject),
+		CreatedAt:      types.StringValue(p.CreatedAt.AsTime().String()),
+		UpdatedAt:      types.StringValue(p.UpdatedAt.AsTime().String()),
+	}
+}
+
+type projectMemberModel struct {
+	Id                  types.String `tfsdk:"id"`
+	Project             types.String `tfsdk:"project"`
+	MemberId            types.String `tfsdk:"member_id"`
+	Role                types.String `tfsdk:"role"`
+	InheritedMembership types.Bool   `tfsdk:"inherited_membership"`
+	CreatedAt           types.String `tfsdk:"created_at"`
+}
+
+func projectMemberFromApi(project string, m *apiv1.ProjectMember) projectMemberModel {
+	return projectMemberModel{
+		Id:                  types.StringValue(projectMemberId(project, m.Id)),
+		Project:             types.StringValue(project),
+		MemberId:            types.StringValue(m.Id),
+		Role:                types.StringValue(shared.ProjectRoleToString(m.Role)),
+		InheritedMembership: types.BoolValue(m.InheritedMembership),
+		CreatedAt:           types.StringValue(m.CreatedAt.AsTime().String()),
+	}
+}
+
+// projectMemberId is the terraform ID of a membership, which is also accepted on import.
+func projectMemberId(project, memberId string) string {
+	return fmt.Sprintf("%s/%s", project, memberId)
+}
+
+type projectInviteModel struct {
+	Project     types.String `tfsdk:"project"`
+	Role        types.String `tfsdk:"role"`
+	Secret      types.String `tfsdk:"secret"`
+	JoinLink    types.String `tfsdk:"join_link"`
+	Joined      types.Bool   `tfsdk:"joined"`
+	ProjectName types.String `tfsdk:"project_name"`
+	Tenant      types.String `tfsdk:"tenant"`
+	ExpiresAt   types.String `tfsdk:"expires_at"`
+	JoinedAt    types.String `tfsdk:"joined_at"`
+}
+
+// projectInviteFromApi converts an invite, the join link is built from the console URL as the API does not return it.
+func projectInviteFromApi(i *apiv1.ProjectInvite, consoleUrl string) projectInviteModel {
+	joinedAt := ""
+	if i.JoinedAt != nil {
+		joinedAt = i.JoinedAt.AsTime().String()
+	}
+	return projectInviteModel{
+		Project:     types.StringValue(i.Project),
+		Role:        types.StringValue(shared.ProjectRoleToString(i.Role)),
+		Secret:      types.StringValue(i.Secret),
+		JoinLink:    types.StringValue(strings.TrimSuffix(consoleUrl, "/") + "/invite/" + i.Secret),
+		Joined:      types.BoolValue(i.Joined),
+		ProjectName: types.StringValue(i.ProjectName),
+		Tenant:      types.StringValue(i.Tenant),
+		ExpiresAt:   types.StringValue(i.ExpiresAt.AsTime().String()),
+		JoinedAt:    types.StringValue(joinedAt),
+	}
+}
+
+// ProjectUsageDataSourceModel describes the data source data model.
+type ProjectUsageDataSourceModel struct {
+	ContentId      types.String              `tfsdk:"id"`
+	Project        types.String              `tfsdk:"project"`
+	From           types.String              `tfsdk:"from"`
+	To             types.String              `tfsdk:"to"`
+	ClusterWorkers []clusterWorkerUsageModel `tfsdk:"cluster_workers"`
+	Ips            []ipUsageModel            `tfsdk:"ips"`
+	Volumes        []storageUsageModel       `tfsdk:"volumes"`
+	Snapshots      []storageUsageModel       `tfsdk:"snapshots"`
+	Totals         *projectUsageTotalsModel  `tfsdk:"totals"`
+}
+
+type clusterWorkerUsageModel struct {
+	ClusterId   types.String  `tfsdk:"cluster_id"`
+	ClusterName types.String  `tfsdk:"cluster_name"`
+	Partition   types.String  `tfsdk:"partition"`
+	MachineType types.String  `tfsdk:"machine_type"`
+	Hours       types.Float64 `tfsdk:"hours"`
+}
+
+type ipUsageModel struct {
+	Id    types.String  `tfsdk:"id"`
+	Ip    types.String  `tfsdk:"ip"`
+	Name  types.String  `tfsdk:"name"`
+	Hours types.Float64 `tfsdk:"hours"`
+}
+
+type storageUsageModel struct {
+	Id        types.String  `tfsdk:"id"`
+	Name      types.String  `tfsdk:"name"`
+	Partition types.String  `tfsdk:"partition"`
+	GibHours  types.Float64 `tfsdk:"gib_hours"`
+}
+
+type projectUsageTotalsModel struct {
+	WorkerHours              types.Float64            `tfsdk:"worker_hours"`
+	WorkerHoursByMachineType map[string]types.Float64 `tfsdk:"worker_hours_by_machine_type"`
+	IpHours                  types.Float64            `tfsdk:"ip_hours"`
+	VolumeGibHours           types.Float64            `tfsdk:"volume_gib_hours"`
+	SnapshotGibHours         types.Float64            `tfsdk:"snapshot_gib_hours"`
+}
+
+func projectUsageListRequest(project, from, to string) (*apiv1.UsageServiceListRequest, error) {
+	fromTime, err := time.Parse(time.RFC3339, from)
+	if err != nil {
+		return nil, fmt.Errorf("from is no RFC3339 timestamp: %w", err)
+	}
+	toTime, err := time.Parse(time.RFC3339, to)
+	if err != nil {
+		return nil, fmt.Errorf("to is no RFC3339 timestamp: %w", err)
+	}
+	if !toTime.After(fromTime) {
+		return nil, fmt.Errorf("to must be after from")
+	}
+	return &apiv1.UsageServiceListRequest{
+		Project: project,
+		From:    timestamppb.New(fromTime),
+		To:      timestamppb.New(toTime),
+	}, nil
+}
+
+// withUsageFromApi sets the line items of all resource types and sums them up per type.
+func (m *ProjectUsageDataSourceModel) withUsageFromApi(u *apiv1.UsageServiceListResponse) {
+	totals := &projectUsageTotalsModel{
+		WorkerHoursByMachineType: map[string]types.Float64{},
+	}
+	var workerHours, ipHours, volumeGibHours, snapshotGibHours float64
+	byMachineType := map[string]float64{}
+
+	m.ClusterWorkers = make([]clusterWorkerUsageModel, 0, len(u.ClusterUsage))
+	for _, c := range u.ClusterUsage {
+		m.ClusterWorkers = append(m.ClusterWorkers, clusterWorkerUsageModel{
+			ClusterId:   types.StringValue(c.Uuid),
+			ClusterName: types.StringValue(c.Name),
+			Partition:   types.StringValue(c.Partition),
+			MachineType: types.StringValue(c.MachineType),
+			Hours:       types.Float64Value(c.WorkerHours),
+		})
+		workerHours += c.WorkerHours
+		byMachineType[c.MachineType] += c.WorkerHours
+	}
+	m.Ips = make([]ipUsageModel, 0, len(u.IpUsage))
+	for _, ip := range u.IpUsage {
+		m.Ips = append(m.Ips, ipUsageModel{
+			Id:    types.StringValue(ip.Uuid),
+			Ip:    types.StringValue(ip.Ip),
+			Name:  types.StringValue(ip.Name),
+			Hours: types.Float64Value(ip.Hours),
+		})
+		ipHours += ip.Hours
+	}
+	m.Volumes = make([]storageUsageModel, 0, len(u.VolumeUsage))
+	for _, v := range u.VolumeUsage {
+		m.Volumes = append(m.Volumes, storageUsageModel{
+			Id:        types.StringValue(v.Uuid),
+			Name:      types.StringValue(v.Name),
+			Partition: types.StringValue(v.Partition),
+			GibHours:  types.Float64Value(v.GibHours),
+		})
+		volumeGibHours += v.GibHours
+	}
+	m.Snapshots = make([]storageUsageModel, 0, len(u.SnapshotUsage))
+	for _, s := range u.SnapshotUsage {
+		m.Snapshots = append(m.Snapshots, storageUsageModel{
+			Id:        types.StringValue(s.Uuid),
+			Name:      types.StringValue(s.Name),
+			Partition: types.StringValue(s.Partition),
+			GibHours:  types.Float64Value(s.GibHours),
+		})
+		snapshotGibHours += s.GibHours
+	}
+
+	totals.WorkerHours = types.Float64Value(workerHours)
+	for machineType, hours := range byMachineType {
+		totals.WorkerHoursByMachineType[machineType] = types.Float64Value(hours)
+	}
+	totals.IpHours = types.Float64Value(ipHours)
+	totals.VolumeGibHours = types.Float64Value(volumeGibHours)
+	totals.SnapshotGibHours = types.Float64Value(snapshotGibHours)
+	m.Totals = totals
+}
+
+// usageContentId hashes all line items, so that the id changes whenever the usage does.
+func (m *ProjectUsageDataSourceModel) usageContentId() string {
+	var items []string
+	for _, c := range m.ClusterWorkers {
+		items = append(items, fmt.Sprintf("cluster/%s/%s/%s/%g", c.ClusterId.ValueString(), c.Partition.ValueString(), c.MachineType.ValueString(), c.Hours.ValueFloat64()))
+	}
+	for _, ip := range m.Ips {
+		items = append(items, fmt.Sprintf("ip/%s/%s/%g", ip.Id.ValueString(), ip.Ip.ValueString(), ip.Hours.ValueFloat64()))
+	}
+	for _, v := range m.Volumes {
+		items = append(items, fmt.Sprintf("volume/%s/%s/%g", v.Id.ValueString(), v.Partition.ValueString(), v.GibHours.ValueFloat64()))
+	}
+	for _, s := range m.Snapshots {
+		items = append(items, fmt.Sprintf("snapshot/%s/%s/%g", s.Id.ValueString(), s.Partition.ValueString(), s.GibHours.ValueFloat64()))
+	}
+	return fmt.Sprintf("%x", sha1.Sum([]byte(strings.Join(items, ","))))
+}
@@ -0,0 +1,123 @@
+package project_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/metal-stack-cloud/terraform-provider-metal/internal/provider"
+)
+
+var (
+	testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+		"metal": providerserver.NewProtocol6WithError(provider.New("test")()),
+	}
+)
+
+func TestAccProjectResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProjectResourceConfig("first description"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("metal_project.test", "name", "tf-acc-test"),
+					resource.TestCheckResourceAttr("metal_project.test", "description", "first description"),
+					resource.TestCheckResourceAttrSet("metal_project.test", "tenant"),
+					resource.TestCheckResourceAttrPair("data.metal_project.test", "id", "metal_project.test", "id"),
+				),
+			},
+			{
+				ResourceName:      "metal_project.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccProjectResourceConfig("second description"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("metal_project.test", "description", "second description"),
+				),
+			},
+		},
+	})
+}
+
+func testAccProjectResourceConfig(description string) string {
+	return `
+resource "metal_project" "test" {
+  name        = "tf-acc-test"
+  description = "` + description + `"
+}
+
+data "metal_project" "test" {
+  name = metal_project.test.name
+}
+`
+}
+
+func TestAccProjectInviteResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProjectInviteResourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("metal_project_invite.test", "role", "viewer"),
+					resource.TestCheckResourceAttr("metal_project_invite.test", "joined", "false"),
+					resource.TestCheckResourceAttrSet("metal_project_invite.test", "secret"),
+					resource.TestCheckResourceAttrPair("metal_project_invite.test", "project", "metal_project.test", "id"),
+				),
+			},
+			{
+				ResourceName:                         "metal_project_invite.test",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "secret",
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return s.RootModule().Resources["metal_project_invite.test"].Primary.Attributes["secret"], nil
+				},
+			},
+		},
+	})
+}
+
+const testAccProjectInviteResourceConfig = `
+resource "metal_project" "test" {
+  name = "tf-acc-test-invite"
+}
+
+resource "metal_project_invite" "test" {
+  project = metal_project.test.id
+  role    = "viewer"
+}
+`
+
+func TestAccProjectUsageDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProjectUsageDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.metal_project_usage.test", "id"),
+					resource.TestCheckResourceAttrSet("data.metal_project_usage.test", "project"),
+					resource.TestCheckResourceAttrSet("data.metal_project_usage.test", "cluster_workers.#"),
+					resource.TestCheckResourceAttrSet("data.metal_project_usage.test", "ips.#"),
+					resource.TestCheckResourceAttrSet("data.metal_project_usage.test", "volumes.#"),
+					resource.TestCheckResourceAttrSet("data.metal_project_usage.test", "snapshots.#"),
+					resource.TestCheckResourceAttrSet("data.metal_project_usage.test", "totals.worker_hours"),
+					resource.TestCheckResourceAttrSet("data.metal_project_usage.test", "totals.ip_hours"),
+				),
+			},
+		},
+	})
+}
+
+const testAccProjectUsageDataSourceConfig = `
+data "metal_project_usage" "test" {
+  from = "2024-05-01T00:00:00Z"
+  to   = "2024-06-01T00:00:00Z"
+}
+`
@@ -0,0 +1,171 @@
+package project
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	apiv1 "github.com/metal-stack-cloud/api/go/api/v1"
+	assert "github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func Test_projectFromApi(t *testing.T) {
+	project := &apiv1.Project{
+		Uuid:             "7c8f1d2e-3a4b-4c5d-8e6f-9a0b1c2d3e4f",
+		Name:             "team-a",
+		Description:      "Workloads of team A",
+		Tenant:           "acme",
+		IsDefaultProject: true,
+		CreatedAt: &timestamppb.Timestamp{
+			Seconds: int64(1707382100),
+		},
+		UpdatedAt: &timestamppb.Timestamp{
+			Seconds: int64(1717932877),
+		},
+	}
+	want := projectModel{
+		Uuid:           basetypes.NewStringValue("7c8f1d2e-3a4b-4c5d-8e6f-9a0b1c2d3e4f"),
+		Name:           basetypes.NewStringValue("team-a"),
+		Description:    basetypes.NewStringValue("Workloads of team A"),
+		Tenant:         basetypes.NewStringValue("acme"),
+		DefaultProject: basetypes.NewBoolValue(true),
+		CreatedAt:      basetypes.NewStringValue("2024-02-08 08:48:20 +0000 UTC"),
+		UpdatedAt:      basetypes.NewStringValue("2024-06-09 11:34:37 +0000 UTC"),
+	}
+
+	assert.Equal(t, want, projectFromApi(project))
+}
+
+func Test_findProjectByName(t *testing.T) {
+	projects := []*apiv1.Project{
+		{Uuid: "1", Name: "staging"},
+		{Uuid: "2", Name: "shared"},
+		{Uuid: "3", Name: "shared"},
+	}
+
+	found, err := findProjectByName(projects, "staging")
+	assert.NoError(t, err)
+	assert.Equal(t, "1", found.Uuid)
+
+	_, err = findProjectByName(projects, "shared")
+	assert.EqualError(t, err, `the project name "shared" is ambiguous, use the project ID instead`)
+
+	_, err = findProjectByName(projects, "production")
+	assert.EqualError(t, err, `no project named "production" found`)
+}
+
+func Test_projectMemberFromApi(t *testing.T) {
+	member := &apiv1.ProjectMember{
+		Id:                  "octocat@github",
+		Role:                apiv1.ProjectRole_PROJECT_ROLE_EDITOR,
+		InheritedMembership: false,
+		CreatedAt: &timestamppb.Timestamp{
+			Seconds: int64(1707382100),
+		},
+	}
+	want := projectMemberModel{
+		Id:                  basetypes.NewStringValue("7c8f1d2e-3a4b-4c5d-8e6f-9a0b1c2d3e4f/octocat@github"),
+		Project:             basetypes.NewStringValue("7c8f1d2e-3a4b-4c5d-8e6f-9a0b1c2d3e4f"),
+		MemberId:            basetypes.NewStringValue("octocat@github"),
+		Role:                basetypes.NewStringValue("editor"),
+		InheritedMembership: basetypes.NewBoolValue(false),
+		CreatedAt:           basetypes.NewStringValue("2024-02-08 08:48:20 +0000 UTC"),
+	}
+
+	assert.Equal(t, want, projectMemberFromApi("7c8f1d2e-3a4b-4c5d-8e6f-9a0b1c2d3e4f", member))
+}
+
+func Test_projectInviteFromApi(t *testing.T) {
+	invite := &apiv1.ProjectInvite{
+		Secret:      "c2VjcmV0",
+		Project:     "7c8f1d2e-3a4b-4c5d-8e6f-9a0b1c2d3e4f",
+		Role:        apiv1.ProjectRole_PROJECT_ROLE_VIEWER,
+		ProjectName: "team-a",
+		Tenant:      "acme",
+		ExpiresAt: &timestamppb.Timestamp{
+			Seconds: int64(1717932877),
+		},
+	}
+	want := projectInviteModel{
+		Project:     basetypes.NewStringValue("7c8f1d2e-3a4b-4c5d-8e6f-9a0b1c2d3e4f"),
+		Role:        basetypes.NewStringValue("viewer"),
+		Secret:      basetypes.NewStringValue("c2VjcmV0"),
+		JoinLink:    basetypes.NewStringValue("https://console.metalstack.cloud/invite/c2VjcmV0"),
+		Joined:      basetypes.NewBoolValue(false),
+		ProjectName: basetypes.NewStringValue("team-a"),
+		Tenant:      basetypes.NewStringValue("acme"),
+		ExpiresAt:   basetypes.NewStringValue("2024-06-09 11:34:37 +0000 UTC"),
+		JoinedAt:    basetypes.NewStringValue(""),
+	}
+
+	assert.Equal(t, want, projectInviteFromApi(invite, "https://console.metalstack.cloud"))
+}
+
+func Test_projectUsageListRequest(t *testing.T) {
+	req, err := projectUsageListRequest("7c8f1d2e-3a4b-4c5d-8e6f-9a0b1c2d3e4f", "2024-02-08T08:48:20Z", "2024-06-09T11:34:37Z")
+	assert.NoError(t, err)
+	assert.Equal(t, &apiv1.UsageServiceListRequest{
+		Project: "7c8f1d2e-3a4b-4c5d-8e6f-9a0b1c2d3e4f",
+		From:    &timestamppb.Timestamp{Seconds: int64(1707382100)},
+		To:      &timestamppb.Timestamp{Seconds: int64(1717932877)},
+	}, req)
+
+	_, err = projectUsageListRequest("7c8f1d2e-3a4b-4c5d-8e6f-9a0b1c2d3e4f", "2024-06-09T11:34:37Z", "2024-02-08T08:48:20Z")
+	assert.EqualError(t, err, "to must be after from")
+
+	_, err = projectUsageListRequest("7c8f1d2e-3a4b-4c5d-8e6f-9a0b1c2d3e4f", "yesterday", "2024-02-08T08:48:20Z")
+	assert.ErrorContains(t, err, "from is no RFC3339 timestamp")
+}
+
+func Test_withUsageFromApi(t *testing.T) {
+	var model ProjectUsageDataSourceModel
+	model.withUsageFromApi(&apiv1.UsageServiceListResponse{
+		ClusterUsage: []*apiv1.ClusterUsage{
+			{Uuid: "a", Name: "prod", Partition: "eqx-mu4", MachineType: "n1-medium-x86", WorkerHours: 720},
+			{Uuid: "a", Name: "prod", Partition: "eqx-mu4", MachineType: "c1-large-x86", WorkerHours: 24},
+			{Uuid: "b", Name: "dev", Partition: "eqx-mu4", MachineType: "n1-medium-x86", WorkerHours: 100.5},
+		},
+		IpUsage: []*apiv1.IPUsage{
+			{Uuid: "c", Ip: "212.34.83.12", Name: "ingress", Hours: 720},
+		},
+		VolumeUsage: []*apiv1.VolumeUsage{
+			{Uuid: "d", Name: "pvc-1", Partition: "eqx-mu4", GibHours: 7200},
+		},
+	})
+
+	assert.Len(t, model.ClusterWorkers, 3)
+	assert.Equal(t, ipUsageModel{
+		Id:    basetypes.NewStringValue("c"),
+		Ip:    basetypes.NewStringValue("212.34.83.12"),
+		Name:  basetypes.NewStringValue("ingress"),
+		Hours: basetypes.NewFloat64Value(720),
+	}, model.Ips[0])
+	assert.Empty(t, model.Snapshots)
+	assert.Equal(t, &projectUsageTotalsModel{
+		WorkerHours: basetypes.NewFloat64Value(844.5),
+		WorkerHoursByMachineType: map[string]basetypes.Float64Value{
+			"n1-medium-x86": basetypes.NewFloat64Value(820.5),
+			"c1-large-x86":  basetypes.NewFloat64Value(24),
+		},
+		IpHours:          basetypes.NewFloat64Value(720),
+		VolumeGibHours:   basetypes.NewFloat64Value(7200),
+		SnapshotGibHours: basetypes.NewFloat64Value(0),
+	}, model.Totals)
+}
+
+func Test_usageContentId(t *testing.T) {
+	usage := func(hours float64) ProjectUsageDataSourceModel {
+		var model ProjectUsageDataSourceModel
+		model.withUsageFromApi(&apiv1.UsageServiceListResponse{
+			IpUsage: []*apiv1.IPUsage{
+				{Uuid: "c", Ip: "212.34.83.12", Name: "ingress", Hours: hours},
+			},
+		})
+		return model
+	}
+
+	first, second := usage(720), usage(720)
+	assert.Equal(t, first.usageContentId(), second.usageContentId())
+	changed := usage(744)
+	assert.NotEqual(t, first.usageContentId(), changed.usageContentId())
+}
@@ -0,0 +1,181 @@
+package project
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	apiv1 "github.com/metal-stack-cloud/api/go/api/v1"
+	"github.com/metal-stack-cloud/terraform-provider-metal/internal/session"
+)
+
+var (
+	_ resource.Resource                = &ProjectResource{}
+	_ resource.ResourceWithConfigure   = &ProjectResource{}
+	_ resource.ResourceWithImportState = &ProjectResource{}
+	_ resource.ResourceWithModifyPlan  = &ProjectResource{}
+)
+
+func NewProjectResource() resource.Resource {
+	return &ProjectResource{}
+}
+
+type ProjectResource struct {
+	session *session.Session
+}
+
+// Metadata implements resource.Resource.
+func (*ProjectResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project"
+}
+
+// Schema implements resource.Resource.
+func (*ProjectResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: projectResourceAttributes(),
+		MarkdownDescription: "Projects group clusters, IP addresses and volumes of a tenant (organization). \n" +
+			"The ID of a created project can be used as `project` of all other resources. \n" +
+			"Required permissions: `Project *`. Can be imported by ID or name.",
+	}
+}
+
+// Configure implements resource.ResourceWithConfigure.
+func (p *ProjectResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	session, ok := req.ProviderData.(*session.Session)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *session.Session, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	p.session = session
+}
+
+// Create implements resource.Resource.
+func (p *ProjectResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan projectModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tenant := plan.Tenant.ValueString()
+	if tenant == "" {
+		providerTenant, err := p.session.ProviderTenant(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to determine the tenant of the provider project", err.Error())
+			return
+		}
+		tenant = providerTenant
+	}
+
+	createdProject, err := p.session.Client.Apiv1().Project().Create(ctx, connect.NewRequest(&apiv1.ProjectServiceCreateRequest{
+		Login:       tenant,
+		Name:        plan.Name.ValueString(),
+		Description: plan.Description.ValueString(),
+	}))
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create project", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, projectFromApi(createdProject.Msg.Project))...)
+}
+
+// Read implements resource.Resource.
+func (p *ProjectResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state projectModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectResp, err := p.session.Client.Apiv1().Project().Get(ctx, connect.NewRequest(&apiv1.ProjectServiceGetRequest{
+		Project: state.Uuid.ValueString(),
+	}))
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get project", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, projectFromApi(projectResp.Msg.Project))...)
+}
+
+// Update implements resource.Resource.
+func (p *ProjectResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state projectModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	var plan projectModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateReq := &apiv1.ProjectServiceUpdateRequest{
+		Project: state.Uuid.ValueString(),
+	}
+	if plan.Name != state.Name {
+		updateReq.Name = plan.Name.ValueStringPointer()
+	}
+	if plan.Description != state.Description {
+		updateReq.Description = plan.Description.ValueStringPointer()
+	}
+
+	updatedProject, err := p.session.Client.Apiv1().Project().Update(ctx, connect.NewRequest(updateReq))
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to update project", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, projectFromApi(updatedProject.Msg.Project))...)
+}
+
+// Delete implements resource.Resource.
+func (p *ProjectResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state projectModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := p.session.Client.Apiv1().Project().Delete(ctx, connect.NewRequest(&apiv1.ProjectServiceDeleteRequest{
+		Project: state.Uuid.ValueString(),
+	}))
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to delete project", err.Error())
+		return
+	}
+}
+
+// ModifyPlan implements resource.ResourceWithModifyPlan.
+func (p *ProjectResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	p.session.CheckPlan(ctx, req, resp, session.ProjectAttribute(path.Root("id")))
+}
+
+// ImportState implements resource.ResourceWithImportState.
+func (p *ProjectResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if _, err := uuid.ParseUUID(req.ID); err == nil {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+
+	list, err := listProjects(ctx, p.session, "")
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get project list", err.Error())
+		return
+	}
+	found, err := findProjectByName(list, req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to find project with name %v", req.ID), err.Error())
+		return
+	}
+	req.ID = found.Uuid
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,355 @@
+package project
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	dataschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/metal-stack-cloud/terraform-provider-metal/internal/shared"
+)
+
+func projectResourceAttributes() map[string]resourceschema.Attribute {
+	return map[string]resourceschema.Attribute{
+		"id": resourceschema.StringAttribute{
+			Computed:    true,
+			Description: "The ID of the project. Can be used as `project` of all other resources.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"name": resourceschema.StringAttribute{
+			Required:    true,
+			Description: "The name of the project.",
+			Validators: []validator.String{
+				stringvalidator.LengthBetween(2, 64),
+			},
+		},
+		"description": resourceschema.StringAttribute{
+			Optional:    true,
+			Computed:    true,
+			Default:     stringdefault.StaticString(""),
+			Description: "A description of the project for your own use.",
+		},
+		"tenant": resourceschema.StringAttribute{
+			Optional:    true,
+			Computed:    true,
+			Description: "The tenant (organization) owning the project. Defaults to the tenant of the provider project. Cannot be moved.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"default_project": resourceschema.BoolAttribute{
+			Computed:    true,
+			Description: "Indicates if this is the default project of the tenant.",
+		},
+		"created_at": resourceschema.StringAttribute{
+			Computed:    true,
+			Description: "Indicates when this project has been created.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"updated_at": resourceschema.StringAttribute{
+			Computed:    true,
+			Description: "Indicates when this project has been updated.",
+		},
+	}
+}
+
+func projectDataSourceAttributes() map[string]dataschema.Attribute {
+	return map[string]dataschema.Attribute{
+		"id": dataschema.StringAttribute{
+			Computed:    true,
+			Optional:    true,
+			Description: "The ID of the project. Can be used to query the project.",
+			Validators: []validator.String{
+				stringvalidator.ExactlyOneOf(path.MatchRoot("id"), path.MatchRoot("name")),
+			},
+		},
+		"name": dataschema.StringAttribute{
+			Computed:    true,
+			Optional:    true,
+			Description: "The name of the project. Can be used to query the project.",
+		},
+		"description": dataschema.StringAttribute{
+			Computed:    true,
+			Description: "A description of the project.",
+		},
+		"tenant": dataschema.StringAttribute{
+			Computed:    true,
+			Description: "The tenant (organization) owning the project.",
+		},
+		"default_project": dataschema.BoolAttribute{
+			Computed:    true,
+			Description: "Indicates if this is the default project of the tenant.",
+		},
+		"created_at": dataschema.StringAttribute{
+			Computed:    true,
+			Description: "Indicates when this project has been created.",
+		},
+		"updated_at": dataschema.StringAttribute{
+			Computed:    true,
+			Description: "Indicates when this project has been updated.",
+		},
+	}
+}
+
+// projectListDataSourceAttributes are the attributes of each project of the list, which cannot be used for querying.
+func projectListDataSourceAttributes() map[string]dataschema.Attribute {
+	attributes := projectDataSourceAttributes()
+	attributes["id"] = dataschema.StringAttribute{
+		Computed:    true,
+		Description: "The ID of the project.",
+	}
+	attributes["name"] = dataschema.StringAttribute{
+		Computed:    true,
+		Description: "The name of the project.",
+	}
+	return attributes
+}
+
+func projectMemberResourceAttributes() map[string]resourceschema.Attribute {
+	return map[string]resourceschema.Attribute{
+		"id": resourceschema.StringAttribute{
+			Computed:    true,
+			Description: "The ID of the membership in the form `project/member_id`.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"project": resourceschema.StringAttribute{
+			Optional:    true,
+			Computed:    true,
+			Description: "The project of the membership. Defaults to the provider project.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"member_id": resourceschema.StringAttribute{
+			Required:    true,
+			Description: "The login of the user, e.g. `octocat@github`. The user must have joined the project already, e.g. through a `metal_project_invite`.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"role": resourceschema.StringAttribute{
+			Required:    true,
+			Description: "The role of the member in the project. Must be one of 'owner', 'editor' or 'viewer'.",
+			Validators: []validator.String{
+				stringvalidator.OneOf(shared.ProjectRoles...),
+			},
+		},
+		"inherited_membership": resourceschema.BoolAttribute{
+			Computed:    true,
+			Description: "Indicates that the membership is inherited from the tenant and not bound to the project itself.",
+		},
+		"created_at": resourceschema.StringAttribute{
+			Computed:    true,
+			Description: "Indicates when the user joined the project.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+	}
+}
+
+func projectInviteResourceAttributes() map[string]resourceschema.Attribute {
+	return map[string]resourceschema.Attribute{
+		"project": resourceschema.StringAttribute{
+			Optional:    true,
+			Computed:    true,
+			Description: "The project to invite to. Defaults to the provider project.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"role": resourceschema.StringAttribute{
+			Required:    true,
+			Description: "The role the invited user gets in the project. Must be one of 'owner', 'editor' or 'viewer'.",
+			Validators: []validator.String{
+				stringvalidator.OneOf(shared.ProjectRoles...),
+			},
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"secret": resourceschema.StringAttribute{
+			Computed:    true,
+			Sensitive:   true,
+			Description: "The secret of the invite. Anyone knowing it can join the project.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"join_link": resourceschema.StringAttribute{
+			Computed:    true,
+			Sensitive:   true,
+			Description: "The link to pass to the invited user to join the project.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"joined": resourceschema.BoolAttribute{
+			Computed:    true,
+			Description: "Indicates if the invite has been accepted.",
+		},
+		"project_name": resourceschema.StringAttribute{
+			Computed:    true,
+			Description: "The name of the project.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"tenant": resourceschema.StringAttribute{
+			Computed:    true,
+			Description: "The tenant (organization) owning the project.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"expires_at": resourceschema.StringAttribute{
+			Computed:    true,
+			Description: "Indicates when the invite expires. Expired and accepted invites are kept in the state, replace the resource to invite again.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"joined_at": resourceschema.StringAttribute{
+			Computed:    true,
+			Description: "Indicates when the invite has been accepted.",
+		},
+	}
+}
+
+func projectUsageDataSourceAttributes() map[string]dataschema.Attribute {
+	return map[string]dataschema.Attribute{
+		"id": dataschema.StringAttribute{
+			Computed: true,
+		},
+		"project": dataschema.StringAttribute{
+			Optional:    true,
+			Computed:    true,
+			Description: "The project to report the usage of. Defaults to the provider project.",
+		},
+		"from": dataschema.StringAttribute{
+			Required:    true,
+			Description: "Start of the time range as RFC3339 timestamp, e.g. `2024-05-01T00:00:00Z`.",
+		},
+		"to": dataschema.StringAttribute{
+			Required:    true,
+			Description: "End of the time range as RFC3339 timestamp, exclusive.",
+		},
+		"cluster_workers": usageListAttribute("The worker hours of every cluster, one item per cluster and machine type.", map[string]dataschema.Attribute{
+			"cluster_id": dataschema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the cluster.",
+			},
+			"cluster_name": dataschema.StringAttribute{
+				Computed:    true,
+				Description: "The name of the cluster.",
+			},
+			"partition": dataschema.StringAttribute{
+				Computed:    true,
+				Description: "The partition of the cluster.",
+			},
+			"machine_type": dataschema.StringAttribute{
+				Computed:    true,
+				Description: "The machine type of the workers.",
+			},
+			"hours": dataschema.Float64Attribute{
+				Computed:    true,
+				Description: "The summed up hours all workers of this machine type were running.",
+			},
+		}),
+		"ips": usageListAttribute("The hours every public IP address was allocated.", map[string]dataschema.Attribute{
+			"id": dataschema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the IP address.",
+			},
+			"ip": dataschema.StringAttribute{
+				Computed:    true,
+				Description: "The IP address.",
+			},
+			"name": dataschema.StringAttribute{
+				Computed:    true,
+				Description: "The name of the IP address.",
+			},
+			"hours": dataschema.Float64Attribute{
+				Computed:    true,
+				Description: "The hours the IP address was allocated.",
+			},
+		}),
+		"volumes":   usageListAttribute("The storage consumption of every volume.", storageUsageAttributes("volume")),
+		"snapshots": usageListAttribute("The storage consumption of every snapshot.", storageUsageAttributes("snapshot")),
+		"totals": dataschema.SingleNestedAttribute{
+			Computed:    true,
+			Description: "The sums of all line items per resource type.",
+			Attributes: map[string]dataschema.Attribute{
+				"worker_hours": dataschema.Float64Attribute{
+					Computed:    true,
+					Description: "The hours of all cluster workers.",
+				},
+				"worker_hours_by_machine_type": dataschema.MapAttribute{
+					Computed:    true,
+					ElementType: types.Float64Type,
+					Description: "The hours of all cluster workers by machine type.",
+				},
+				"ip_hours": dataschema.Float64Attribute{
+					Computed:    true,
+					Description: "The hours of all IP addresses.",
+				},
+				"volume_gib_hours": dataschema.Float64Attribute{
+					Computed:    true,
+					Description: "The GiB hours of all volumes.",
+				},
+				"snapshot_gib_hours": dataschema.Float64Attribute{
+					Computed:    true,
+					Description: "The GiB hours of all snapshots.",
+				},
+			},
+		},
+	}
+}
+
+// usageListAttribute is a list of usage line items with the given attributes.
+func usageListAttribute(description string, attributes map[string]dataschema.Attribute) dataschema.ListNestedAttribute {
+	return dataschema.ListNestedAttribute{
+		Computed:    true,
+		Description: description,
+		NestedObject: dataschema.NestedAttributeObject{
+			Attributes: attributes,
+		},
+	}
+}
+
+// storageUsageAttributes are the attributes of the usage of a volume or snapshot, given as kind.
+func storageUsageAttributes(kind string) map[string]dataschema.Attribute {
+	return map[string]dataschema.Attribute{
+		"id": dataschema.StringAttribute{
+			Computed:    true,
+			Description: fmt.Sprintf("The ID of the %s.", kind),
+		},
+		"name": dataschema.StringAttribute{
+			Computed:    true,
+			Description: fmt.Sprintf("The name of the %s.", kind),
+		},
+		"partition": dataschema.StringAttribute{
+			Computed:    true,
+			Description: fmt.Sprintf("The partition of the %s.", kind),
+		},
+		"gib_hours": dataschema.Float64Attribute{
+			Computed:    true,
+			Description: fmt.Sprintf("The size of the %s in GiB multiplied by the hours it existed in the time range.", kind),
+		},
+	}
+}
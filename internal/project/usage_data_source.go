@@ -0,0 +1,87 @@
+package project
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/metal-stack-cloud/terraform-provider-metal/internal/session"
+)
+
+var (
+	_ datasource.DataSource              = &ProjectUsageDataSource{}
+	_ datasource.DataSourceWithConfigure = &ProjectUsageDataSource{}
+)
+
+func NewProjectUsageDataSource() datasource.DataSource {
+	return &ProjectUsageDataSource{}
+}
+
+// ProjectUsageDataSource defines the data source implementation.
+type ProjectUsageDataSource struct {
+	session *session.Session
+}
+
+// Metadata implements datasource.DataSource.
+func (*ProjectUsageDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project_usage"
+}
+
+// Schema implements datasource.DataSource.
+func (*ProjectUsageDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reports the consumption of clusters, IP addresses, volumes and snapshots of a project in a time range, " +
+			"e.g. to export it into a reporting pipeline. Usage is reported as consumed, not as billed. \n" +
+			"Required permissions: `Usage List`.",
+		Attributes: projectUsageDataSourceAttributes(),
+	}
+}
+
+// Configure implements datasource.DataSourceWithConfigure.
+func (p *ProjectUsageDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	session, ok := req.ProviderData.(*session.Session)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *session.Session, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	p.session = session
+}
+
+// Read implements datasource.DataSource.
+func (p *ProjectUsageDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ProjectUsageDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Project.ValueString() == "" {
+		data.Project = types.StringValue(p.session.Project)
+	}
+	listReq, err := projectUsageListRequest(data.Project.ValueString(), data.From.ValueString(), data.To.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid time range", err.Error())
+		return
+	}
+
+	usage, err := p.session.Client.Apiv1().Usage().List(ctx, connect.NewRequest(listReq))
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get project usage", err.Error())
+		return
+	}
+	data.withUsageFromApi(usage.Msg)
+
+	data.ContentId = types.StringValue(data.usageContentId())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
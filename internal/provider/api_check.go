@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	apiv1 "github.com/metal-stack-cloud/api/go/api/v1"
+	client "github.com/metal-stack-cloud/api/go/client"
+	apiinfo "github.com/metal-stack-cloud/terraform-provider-metal/internal/api_info"
+)
+
+// checkApi fails early if the API is too old for this provider and warns if any of the services it uses is unhealthy,
+// instead of failing deep inside of an operation with a vague error.
+func checkApi(ctx context.Context, apiClient client.Client) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	versionResp, err := apiClient.Apiv1().Version().Get(ctx, connect.NewRequest(&apiv1.VersionServiceGetRequest{}))
+	if err != nil {
+		diags.AddError("Unable to reach the metalstack.cloud API", err.Error())
+		return diags
+	}
+	serverVersion := versionResp.Msg.GetVersion()
+	clientVersion := apiinfo.ClientVersion()
+	tflog.Info(ctx, "connected to metalstack.cloud api", map[string]any{
+		"version":        serverVersion.GetVersion(),
+		"revision":       serverVersion.Revision,
+		"client_version": clientVersion,
+	})
+	if err := apiinfo.CheckCompatibility(serverVersion.GetVersion()); errors.Is(err, apiinfo.ErrUnknownVersion) {
+		tflog.Warn(ctx, "skipping the metalstack.cloud api version check", map[string]any{"reason": err.Error()})
+	} else if err != nil {
+		diags.AddError("Incompatible metalstack.cloud API", err.Error())
+		return diags
+	}
+
+	healthResp, err := apiClient.Apiv1().Health().Get(ctx, connect.NewRequest(&apiv1.HealthServiceGetRequest{}))
+	if err != nil {
+		diags.AddWarning("Unable to check the health of the metalstack.cloud API", err.Error())
+		return diags
+	}
+	for _, service := range apiinfo.UnhealthyServices(healthResp.Msg.GetHealth(), apiinfo.UsedServices...) {
+		diags.AddWarning(
+			"Unhealthy metalstack.cloud API service",
+			fmt.Sprintf("The %s service reports to be %s: %s. Operations depending on it may fail.", apiinfo.ServiceName(service.Name), apiinfo.ServiceStatusName(service.Status), service.Message),
+		)
+	}
+	return diags
+}
@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"connectrpc.com/connect"
+	"github.com/hashicorp/go-uuid"
+	apiv1 "github.com/metal-stack-cloud/api/go/api/v1"
+	client "github.com/metal-stack-cloud/api/go/client"
+)
+
+// projectResolver maps project names to UUIDs. The projects accessible with the api token are listed at most once.
+// It is shared by all resources through the session and safe for concurrent use.
+type projectResolver struct {
+	client client.Client
+
+	mu       sync.Mutex
+	projects []*apiv1.Project
+}
+
+func (r *projectResolver) list(ctx context.Context) ([]*apiv1.Project, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.projects != nil {
+		return r.projects, nil
+	}
+
+	projectResp, err := r.client.Apiv1().Project().List(ctx, connect.NewRequest(&apiv1.ProjectServiceListRequest{}))
+	if err != nil {
+		return nil, fmt.Errorf("unable to list projects: %w", err)
+	}
+	r.projects = projectResp.Msg.GetProjects()
+	return r.projects, nil
+}
+
+// resolve returns the UUID of a project given either by UUID or by name.
+func (r *projectResolver) resolve(ctx context.Context, nameOrId string) (string, error) {
+	if _, err := uuid.ParseUUID(nameOrId); err == nil {
+		return nameOrId, nil
+	}
+
+	projects, err := r.list(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	var matches []*apiv1.Project
+	for _, p := range projects {
+		if p.Name == nameOrId {
+			matches = append(matches, p)
+		}
+	}
+	switch len(matches) {
+	case 1:
+		return matches[0].Uuid, nil
+	case 0:
+		return "", fmt.Errorf("no project named %q found, the api token has access to: %s", nameOrId, describeProjects(projects))
+	default:
+		return "", fmt.Errorf("the project name %q is ambiguous, use one of the project IDs instead: %s", nameOrId, describeProjects(matches))
+	}
+}
+
+// candidates describes all projects accessible with the api token, to be shown in error messages.
+func (r *projectResolver) candidates(ctx context.Context) string {
+	projects, err := r.list(ctx)
+	if err != nil {
+		return err.Error()
+	}
+	return describeProjects(projects)
+}
+
+func describeProjects(projects []*apiv1.Project) string {
+	if len(projects) == 0 {
+		return "none"
+	}
+	descriptions := make([]string, 0, len(projects))
+	for _, p := range projects {
+		descriptions = append(descriptions, fmt.Sprintf("%s (%s)", p.Name, p.Uuid))
+	}
+	return strings.Join(descriptions, ", ")
+}
@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	apiv1 "github.com/metal-stack-cloud/api/go/api/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_projectResolver_resolve(t *testing.T) {
+	resolver := &projectResolver{
+		projects: []*apiv1.Project{
+			{Uuid: "8b0d7d0e-6f5b-4b8a-9f1e-0c5a3a0b6f11", Name: "staging"},
+			{Uuid: "1f3e9c2a-4c1d-4e55-8a7b-2d6f0e9b7c22", Name: "production"},
+			{Uuid: "5a6b7c8d-1e2f-4a3b-9c4d-5e6f7a8b9c33", Name: "shared"},
+			{Uuid: "9d8c7b6a-5f4e-4d3c-8b2a-1f0e9d8c7b44", Name: "shared"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		nameOrId string
+		want     string
+		wantErr  string
+	}{
+		{
+			name:     "uuid is passed through",
+			nameOrId: "0e2b4f6a-8c1d-4e3f-a5b7-c9d1e3f5a7b9",
+			want:     "0e2b4f6a-8c1d-4e3f-a5b7-c9d1e3f5a7b9",
+		},
+		{
+			name:     "resolve by name",
+			nameOrId: "production",
+			want:     "1f3e9c2a-4c1d-4e55-8a7b-2d6f0e9b7c22",
+		},
+		{
+			name:     "unknown name lists candidates",
+			nameOrId: "prod",
+			wantErr:  `no project named "prod" found, the api token has access to: staging (8b0d7d0e-6f5b-4b8a-9f1e-0c5a3a0b6f11), production (1f3e9c2a-4c1d-4e55-8a7b-2d6f0e9b7c22), shared (5a6b7c8d-1e2f-4a3b-9c4d-5e6f7a8b9c33), shared (9d8c7b6a-5f4e-4d3c-8b2a-1f0e9d8c7b44)`,
+		},
+		{
+			name:     "ambiguous name",
+			nameOrId: "shared",
+			wantErr:  `the project name "shared" is ambiguous, use one of the project IDs instead: shared (5a6b7c8d-1e2f-4a3b-9c4d-5e6f7a8b9c33), shared (9d8c7b6a-5f4e-4d3c-8b2a-1f0e9d8c7b44)`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolver.resolve(context.Background(), tt.nameOrId)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
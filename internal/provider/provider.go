@@ -5,29 +5,42 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	"net/url"
 	"os"
 	"slices"
+	"strconv"
+	"strings"
 
 	"connectrpc.com/connect"
 	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/golang-jwt/jwt/v5"
 	apiv1 "github.com/metal-stack-cloud/api/go/api/v1"
 	client "github.com/metal-stack-cloud/api/go/client"
+	apiinfo "github.com/metal-stack-cloud/terraform-provider-metal/internal/api_info"
 	"github.com/metal-stack-cloud/terraform-provider-metal/internal/asset"
+	"github.com/metal-stack-cloud/terraform-provider-metal/internal/audit"
 	cluster "github.com/metal-stack-cloud/terraform-provider-metal/internal/cluster"
+	currentuser "github.com/metal-stack-cloud/terraform-provider-metal/internal/current_user"
 	"github.com/metal-stack-cloud/terraform-provider-metal/internal/kubeconfig"
+	projects "github.com/metal-stack-cloud/terraform-provider-metal/internal/project"
 	ipaddress "github.com/metal-stack-cloud/terraform-provider-metal/internal/public_ip"
 	session "github.com/metal-stack-cloud/terraform-provider-metal/internal/session"
 	"github.com/metal-stack-cloud/terraform-provider-metal/internal/shared"
 	"github.com/metal-stack-cloud/terraform-provider-metal/internal/snapshot"
+	"github.com/metal-stack-cloud/terraform-provider-metal/internal/tenant"
+	"github.com/metal-stack-cloud/terraform-provider-metal/internal/token"
 	"github.com/metal-stack-cloud/terraform-provider-metal/internal/volume"
 )
 
@@ -38,6 +51,9 @@ var (
 	project                   = ""
 )
 
+// defaultConsoleUrl is used if the console cannot be derived from the API URL.
+const defaultConsoleUrl = "https://console.metalstack.cloud"
+
 // MetalstackCloudProvider defines the provider implementation.
 type MetalstackCloudProvider struct {
 	// version is set to the provider version on release, "dev" when the
@@ -53,8 +69,15 @@ type MetalstackCloudProvider struct {
 
 // MetalstackCloudProviderModel describes the provider data model.
 type MetalstackCloudProviderModel struct {
-	ApiToken types.String `tfsdk:"api_token"`
-	Project  types.String `tfsdk:"project"`
+	ApiToken              types.String            `tfsdk:"api_token"`
+	Project               types.String            `tfsdk:"project"`
+	MaxConcurrentRequests types.Int64             `tfsdk:"max_concurrent_requests"`
+	RequestsPerSecond     types.Float64           `tfsdk:"requests_per_second"`
+	ListCache             types.Bool              `tfsdk:"list_cache"`
+	ReadOnly              types.Bool              `tfsdk:"read_only"`
+	AllowedProjects       []types.String          `tfsdk:"allowed_projects"`
+	SkipApiCheck          types.Bool              `tfsdk:"skip_api_check"`
+	DefaultLabels         map[string]types.String `tfsdk:"default_labels"`
 }
 
 func (p *MetalstackCloudProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -75,8 +98,49 @@ func (p *MetalstackCloudProvider) Schema(ctx context.Context, req provider.Schem
 				Sensitive:           true,
 			},
 			"project": schema.StringAttribute{
-				MarkdownDescription: "The project to use for authentication. Defaults to `METAL_STACK_CLOUD_PROJECT` or derived from `api_token`.",
+				MarkdownDescription: "The project to use, given by ID or name. Defaults to `METAL_STACK_CLOUD_PROJECT` or derived from `api_token`.",
+				Optional:            true,
+			},
+			"skip_api_check": schema.BoolAttribute{
+				MarkdownDescription: "Skip checking the version and health of the API when the provider is configured. " +
+					"By default the provider fails early if the API is older than required and warns about unhealthy services. Defaults to `METAL_STACK_CLOUD_SKIP_API_CHECK` or `false`.",
+				Optional: true,
+			},
+			"allowed_projects": schema.ListAttribute{
+				MarkdownDescription: "Guards against working in the wrong project: the provider refuses to run if `project` is not part of this list and resources fail to plan in any other project. Tenant members affect all projects of the tenant and cannot be changed at all. " +
+					"Projects can be given by ID or name. Defaults to the comma separated `METAL_STACK_CLOUD_ALLOWED_PROJECTS`, all projects are allowed if empty.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"default_labels": schema.MapAttribute{
+				MarkdownDescription: "Labels merged into every public IP address, e.g. to tag all addresses with a cost center. Labels set on the resource take precedence.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of API requests in flight at the same time. Defaults to `METAL_STACK_CLOUD_MAX_CONCURRENT_REQUESTS`, `0` means unlimited.",
 				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"requests_per_second": schema.Float64Attribute{
+				MarkdownDescription: "The maximum number of API requests sent per second. Defaults to `METAL_STACK_CLOUD_REQUESTS_PER_SECOND`, `0` means unlimited. " +
+					"Rate limit responses of the API are retried, honoring its `Retry-After` hint.",
+				Optional: true,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0),
+				},
+			},
+			"list_cache": schema.BoolAttribute{
+				MarkdownDescription: "Memoize list responses per project for a short time, so that reading many resources during a single plan or apply only needs one list request per kind. " +
+					"Changes made by the provider invalidate the cache. Defaults to `METAL_STACK_CLOUD_LIST_CACHE` or `false`.",
+				Optional: true,
+			},
+			"read_only": schema.BoolAttribute{
+				MarkdownDescription: "Guarantee that the provider never changes anything, e.g. for drift detection with a token that could. " +
+					"Every API call that is not a known read is rejected, including streams, and plans that would change a resource fail. Defaults to `METAL_STACK_CLOUD_READ_ONLY` or `false`.",
+				Optional: true,
 			},
 		},
 	}
@@ -118,7 +182,7 @@ func (p *MetalstackCloudProvider) Configure(ctx context.Context, req provider.Co
 	if !data.ApiToken.IsNull() {
 		apiToken = data.ApiToken.ValueString()
 	}
-	err := assumeDefaultsFromApiToken(apiToken)
+	tokenClaims, tokenType, err := assumeDefaultsFromApiToken(apiToken)
 	if err != nil {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("api_token"),
@@ -139,18 +203,95 @@ func (p *MetalstackCloudProvider) Configure(ctx context.Context, req provider.Co
 		)
 	}
 
+	maxConcurrentRequests, err := int64FromEnv("METAL_STACK_CLOUD_MAX_CONCURRENT_REQUESTS")
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_concurrent_requests"),
+			"Invalid METAL_STACK_CLOUD_MAX_CONCURRENT_REQUESTS",
+			err.Error(),
+		)
+	}
+	if !data.MaxConcurrentRequests.IsNull() {
+		maxConcurrentRequests = data.MaxConcurrentRequests.ValueInt64()
+	}
+	requestsPerSecond, err := float64FromEnv("METAL_STACK_CLOUD_REQUESTS_PER_SECOND")
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("requests_per_second"),
+			"Invalid METAL_STACK_CLOUD_REQUESTS_PER_SECOND",
+			err.Error(),
+		)
+	}
+	if !data.RequestsPerSecond.IsNull() {
+		requestsPerSecond = data.RequestsPerSecond.ValueFloat64()
+	}
+	limiter := session.NewLimiter(maxConcurrentRequests, requestsPerSecond)
+
+	listCache, err := boolFromEnv("METAL_STACK_CLOUD_LIST_CACHE")
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("list_cache"),
+			"Invalid METAL_STACK_CLOUD_LIST_CACHE",
+			err.Error(),
+		)
+	}
+	if !data.ListCache.IsNull() {
+		listCache = data.ListCache.ValueBool()
+	}
+	readOnly, err := boolFromEnv("METAL_STACK_CLOUD_READ_ONLY")
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("read_only"),
+			"Invalid METAL_STACK_CLOUD_READ_ONLY",
+			err.Error(),
+		)
+	}
+	if !data.ReadOnly.IsNull() {
+		readOnly = data.ReadOnly.ValueBool()
+	}
+
+	interceptors := []connect.Interceptor{}
+	if readOnly {
+		interceptors = append(interceptors, session.ReadOnlyInterceptor())
+	}
+	var cache *session.ListCache
+	if listCache {
+		cache = session.NewListCache(session.DefaultListCacheTTL)
+		interceptors = append(interceptors, cache.Interceptor())
+	}
+	interceptors = append(interceptors, limiter.Interceptor())
+
 	debugLevel := slog.LevelInfo
 	if shared.Debug {
 		debugLevel = slog.LevelDebug
 	}
 
 	apiClient := client.New(&client.DialConfig{
-		BaseURL:   apiUrl,
-		Token:     apiToken,
-		UserAgent: "terraform-provider-metal/" + p.version,
-		Log:       slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: debugLevel})),
+		BaseURL:      apiUrl,
+		Token:        apiToken,
+		UserAgent:    "terraform-provider-metal/" + p.version,
+		Log:          slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: debugLevel})),
+		Interceptors: interceptors,
 	})
 
+	skipApiCheck, err := boolFromEnv("METAL_STACK_CLOUD_SKIP_API_CHECK")
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("skip_api_check"),
+			"Invalid METAL_STACK_CLOUD_SKIP_API_CHECK",
+			err.Error(),
+		)
+	}
+	if !data.SkipApiCheck.IsNull() {
+		skipApiCheck = data.SkipApiCheck.ValueBool()
+	}
+	if !skipApiCheck {
+		resp.Diagnostics.Append(checkApi(ctx, apiClient)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	err = assumeDefaultsFromApiClient(ctx, apiClient)
 	if err != nil {
 		resp.Diagnostics.AddAttributeError(
@@ -160,21 +301,80 @@ func (p *MetalstackCloudProvider) Configure(ctx context.Context, req provider.Co
 		)
 	}
 
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resolver := &projectResolver{client: apiClient}
 	if project == "" {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("project"),
 			"Missing metalstack.cloud project",
 			"The provider cannot create the metalstack.cloud API client as there is an unknown configuration value for the metalstack.cloud API project. "+
-				"Either target apply the source of the value first, set the value statically in the configuration, or use the METAL_STACK_CLOUD_PROJECT environment variable.",
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the METAL_STACK_CLOUD_PROJECT environment variable. "+
+				"The api token has access to: "+resolver.candidates(ctx),
 		)
+		return
+	}
+	project, err = resolver.resolve(ctx, project)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("project"),
+			"Invalid metalstack.cloud project",
+			err.Error(),
+		)
+		return
+	}
+
+	allowedProjects := []string{}
+	if env := os.Getenv("METAL_STACK_CLOUD_ALLOWED_PROJECTS"); env != "" {
+		allowedProjects = strings.Split(env, ",")
+	}
+	if data.AllowedProjects != nil {
+		allowedProjects = allowedProjects[:0]
+		for _, allowed := range data.AllowedProjects {
+			allowedProjects = append(allowedProjects, allowed.ValueString())
+		}
+	}
+	for i, allowed := range allowedProjects {
+		allowedProjects[i], err = resolver.resolve(ctx, strings.TrimSpace(allowed))
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("allowed_projects"),
+				"Invalid allowed project",
+				err.Error(),
+			)
+		}
+	}
+	if len(allowedProjects) > 0 && !slices.Contains(allowedProjects, project) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("project"),
+			"Project not allowed",
+			fmt.Sprintf("The project %q is not part of allowed_projects, refusing to work with it.", project),
+		)
+	}
+
+	defaultLabels := map[string]string{}
+	for key, value := range data.DefaultLabels {
+		defaultLabels[key] = value.ValueString()
 	}
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 	session := &session.Session{
-		Client:  apiClient,
-		Project: project,
+		Client:   apiClient,
+		Project:  project,
+		Limiter:  limiter,
+		Cache:    cache,
+		ReadOnly: readOnly,
+
+		AllowedProjects: allowedProjects,
+		ResolveProject:  resolver.resolve,
+		TokenClaims:     tokenClaims,
+		TokenType:       tokenType,
+		ConsoleUrl:      consoleUrlFromApiUrl(apiUrl),
+		DefaultLabels:   defaultLabels,
 	}
 	resp.DataSourceData = session
 	resp.ResourceData = session
@@ -184,17 +384,37 @@ func (p *MetalstackCloudProvider) Resources(ctx context.Context) []func() resour
 	return []func() resource.Resource{
 		cluster.NewClusterResource,
 		ipaddress.NewPublicIpResource,
+		ipaddress.NewPublicIpPoolResource,
+		projects.NewProjectResource,
+		projects.NewProjectMemberResource,
+		projects.NewProjectInviteResource,
+		tenant.NewTenantMemberResource,
+		token.NewTokenResource,
 	}
 }
 
 func (p *MetalstackCloudProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		cluster.NewClusterDataSource,
+		cluster.NewClusterCostEstimateDataSource,
 		ipaddress.NewPublicIpDataSource,
+		ipaddress.NewPublicIpSingleDataSource,
 		volume.NewVolumeDataSource,
 		snapshot.NewSnapshotDataSource,
 		kubeconfig.NewKubeconfigDataSource,
 		asset.NewAssetDataSource,
+		asset.NewMachineTypeDataSource,
+		asset.NewKubernetesVersionDataSource,
+		asset.NewRegionDataSource,
+		asset.NewPartitionDataSource,
+		apiinfo.NewApiInfoDataSource,
+		projects.NewProjectDataSource,
+		projects.NewProjectListDataSource,
+		projects.NewProjectUsageDataSource,
+		tenant.NewTenantDataSource,
+		token.NewTokenScopeDataSource,
+		currentuser.NewCurrentUserDataSource,
+		audit.NewAuditTracesDataSource,
 	}
 }
 
@@ -207,17 +427,57 @@ func New(version string) func() provider.Provider {
 	}
 }
 
-func assumeDefaultsFromApiToken(apiToken string) error {
+func int64FromEnv(key string) (int64, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.ParseInt(value, 10, 64)
+}
+
+func float64FromEnv(key string) (float64, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.ParseFloat(value, 64)
+}
+
+func boolFromEnv(key string) (bool, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}
+
+// consoleUrlFromApiUrl returns the console belonging to the API, which is served next to it, e.g. console.metalstack.cloud for api.metalstack.cloud.
+// The API does not return links into the console.
+func consoleUrlFromApiUrl(apiUrl string) string {
+	u, err := url.Parse(apiUrl)
+	if err != nil || !strings.HasPrefix(u.Host, "api.") {
+		return defaultConsoleUrl
+	}
+	return (&url.URL{Scheme: u.Scheme, Host: "console." + strings.TrimPrefix(u.Host, "api.")}).String()
+}
+
+// apiTokenClaims are the claims of an api token, the type tells machine tokens from tokens of a console login.
+type apiTokenClaims struct {
+	jwt.RegisteredClaims
+	Type string `json:"type"`
+}
+
+func assumeDefaultsFromApiToken(apiToken string) (*jwt.RegisteredClaims, string, error) {
 	parser := jwt.NewParser()
 
-	var claims jwt.RegisteredClaims
+	var claims apiTokenClaims
 	_, _, err := parser.ParseUnverified(apiToken, &claims)
 	if err != nil {
-		return err
+		return nil, "", err
 	}
 
 	apiUrl = claims.Issuer
-	return nil
+	return &claims.RegisteredClaims, claims.Type, nil
 }
 
 func assumeDefaultsFromApiClient(ctx context.Context, apiClient client.Client) error {
@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	session "github.com/metal-stack-cloud/terraform-provider-metal/internal/session"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_consoleUrlFromApiUrl(t *testing.T) {
+	tests := []struct {
+		name   string
+		apiUrl string
+		want   string
+	}{
+		{
+			name:   "production",
+			apiUrl: "https://api.metalstack.cloud",
+			want:   "https://console.metalstack.cloud",
+		},
+		{
+			name:   "other environment",
+			apiUrl: "https://api.test.metalstack.cloud/",
+			want:   "https://console.test.metalstack.cloud",
+		},
+		{
+			name:   "not served next to the console",
+			apiUrl: "http://localhost:8080",
+			want:   "https://console.metalstack.cloud",
+		},
+		{
+			name:   "empty",
+			apiUrl: "",
+			want:   "https://console.metalstack.cloud",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, consoleUrlFromApiUrl(tt.apiUrl))
+		})
+	}
+}
+
+func Test_assumeDefaultsFromApiToken(t *testing.T) {
+	defer func(u string) { apiUrl = u }(apiUrl)
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodNone, apiTokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:      "a1b2c3d4-e5f6-4a7b-8c9d-0e1f2a3b4c5d",
+			Subject: "octocat@github",
+			Issuer:  "https://api.metalstack.cloud",
+		},
+		Type: "api",
+	}).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	assert.NoError(t, err)
+
+	claims, tokenType, err := assumeDefaultsFromApiToken(token)
+	assert.NoError(t, err)
+	assert.Equal(t, "octocat@github", claims.Subject)
+	assert.Equal(t, session.MachineTokenType, tokenType)
+	assert.Equal(t, "https://api.metalstack.cloud", apiUrl)
+}
@@ -6,12 +6,12 @@ import (
 	"fmt"
 	"strings"
 
-	"connectrpc.com/connect"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	apiv1 "github.com/metal-stack-cloud/api/go/api/v1"
 	"github.com/metal-stack-cloud/terraform-provider-metal/internal/session"
 )
 
@@ -46,6 +46,31 @@ func (*PublicIpDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 			"id": schema.StringAttribute{
 				Computed: true,
 			},
+			"project": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The project to list the addresses of. Defaults to the provider project.",
+			},
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list addresses with this name.",
+			},
+			"type": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list addresses of this type, either 'static' or 'ephemeral'.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(ipTypeEphemeral, ipTypeStatic),
+				},
+			},
+			"tags": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Only list addresses having all of these tags.",
+			},
+			"network": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list addresses bound to this network.",
+			},
 			"items": schema.ListNestedAttribute{
 				Computed:    true,
 				Description: "All public IP addresses",
@@ -85,18 +110,25 @@ func (ip *PublicIpDataSource) Read(ctx context.Context, req datasource.ReadReque
 		return
 	}
 
-	ipResp, err := ip.session.Client.Apiv1().IP().List(ctx, connect.NewRequest(&apiv1.IPServiceListRequest{
-		Project: ip.session.Project,
-	}))
+	if data.Project.ValueString() == "" {
+		data.Project = types.StringValue(ip.session.Project)
+	}
+	ips, err := listIps(ctx, ip.session, data.Project.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Unable to read public IP Addresses", err.Error())
 		return
 	}
 	tflog.Trace(ctx, "read public ip addresses")
 
-	data.Items = make([]publicIpModel, 0, len(ipResp.Msg.Ips))
-	ids := make([]string, 0, len(ipResp.Msg.Ips))
-	for _, ip := range ipResp.Msg.Ips {
+	tags := make([]string, 0, len(data.Tags))
+	for _, tag := range data.Tags {
+		tags = append(tags, tag.ValueString())
+	}
+	ips = filterIps(ips, data.Name.ValueString(), data.Type.ValueString(), data.Network.ValueString(), tags)
+
+	data.Items = make([]publicIpModel, 0, len(ips))
+	ids := make([]string, 0, len(ips))
+	for _, ip := range ips {
 		data.Items = append(data.Items, publicIpFromApi(ip))
 		ids = append(ids, ip.Ip)
 	}
@@ -0,0 +1,160 @@
+package ipaddress
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"connectrpc.com/connect"
+	apiv1 "github.com/metal-stack-cloud/api/go/api/v1"
+	"github.com/metal-stack-cloud/api/go/api/v1/apiv1connect"
+	"github.com/metal-stack-cloud/terraform-provider-metal/internal/session"
+)
+
+// listIps returns all IPs of the project, served from the session cache if enabled.
+func listIps(ctx context.Context, s *session.Session, project string) ([]*apiv1.IP, error) {
+	return session.CachedList(ctx, s.Cache, apiv1connect.IPServiceName, project, func(ctx context.Context) ([]*apiv1.IP, error) {
+		ipResp, err := s.Client.Apiv1().IP().List(ctx, connect.NewRequest(&apiv1.IPServiceListRequest{
+			Project: project,
+		}))
+		if err != nil {
+			return nil, err
+		}
+		return ipResp.Msg.Ips, nil
+	})
+}
+
+// listIpsUncached always asks the API, e.g. to see the latest tags of the cloud controller manager before releasing addresses.
+func listIpsUncached(ctx context.Context, s *session.Session, project string) ([]*apiv1.IP, error) {
+	ipResp, err := s.Client.Apiv1().IP().List(ctx, connect.NewRequest(&apiv1.IPServiceListRequest{
+		Project: project,
+	}))
+	if err != nil {
+		return nil, err
+	}
+	return ipResp.Msg.Ips, nil
+}
+
+// getIpUncached always asks the API, e.g. to see the latest tags of the cloud controller manager before changing or deleting an address.
+func getIpUncached(ctx context.Context, s *session.Session, project, uuid string) (*apiv1.IP, error) {
+	ipResp, err := s.Client.Apiv1().IP().Get(ctx, connect.NewRequest(&apiv1.IPServiceGetRequest{
+		Uuid:    uuid,
+		Project: project,
+	}))
+	if err != nil {
+		return nil, err
+	}
+	return ipResp.Msg.Ip, nil
+}
+
+// getIp looks up the IP in the cached list snapshot first and only asks the API if it is not part of it.
+func getIp(ctx context.Context, s *session.Session, project, uuid string) (*apiv1.IP, error) {
+	if s.Cache != nil {
+		ips, err := listIps(ctx, s, project)
+		if err != nil {
+			return nil, err
+		}
+		for _, ip := range ips {
+			if ip.Uuid == uuid {
+				return ip, nil
+			}
+		}
+	}
+
+	ipResp, err := s.Client.Apiv1().IP().Get(ctx, connect.NewRequest(&apiv1.IPServiceGetRequest{
+		Uuid:    uuid,
+		Project: project,
+	}))
+	if err != nil {
+		return nil, err
+	}
+	return ipResp.Msg.Ip, nil
+}
+
+// filterIps returns the IPs matching all given filters, empty filters match every IP. An IP matches the tags if it
+// has all of them.
+func filterIps(ips []*apiv1.IP, name, ipType, network string, tags []string) []*apiv1.IP {
+	var result []*apiv1.IP
+	for _, ip := range ips {
+		if name != "" && ip.Name != name {
+			continue
+		}
+		if ipType != "" && ipTypeToString(ip.Type) != ipType {
+			continue
+		}
+		if network != "" && ip.Network != network {
+			continue
+		}
+		if !containsAll(ip.Tags, tags) {
+			continue
+		}
+		result = append(result, ip)
+	}
+	return result
+}
+
+func containsAll(tags, wanted []string) bool {
+	for _, w := range wanted {
+		if !slices.Contains(tags, w) {
+			return false
+		}
+	}
+	return true
+}
+
+// findIp returns the single IP with the given id, name or address, names are not unique and may be ambiguous.
+func findIp(ips []*apiv1.IP, id, name, address string) (*apiv1.IP, error) {
+	var found []*apiv1.IP
+	for _, ip := range ips {
+		if (id != "" && ip.Uuid == id) || (name != "" && ip.Name == name) || (address != "" && ip.Ip == address) {
+			found = append(found, ip)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("no public ip found with id %q, name %q or address %q", id, name, address)
+	case 1:
+		return found[0], nil
+	default:
+		ids := make([]string, 0, len(found))
+		for _, ip := range found {
+			ids = append(ids, ip.Uuid)
+		}
+		return nil, fmt.Errorf("%d public ips match, look them up by id instead: %s", len(found), strings.Join(ids, ", "))
+	}
+}
+
+// poolIps returns the addresses of the pool in allocation order, oldest first. Addresses allocated at the same time are ordered by name.
+func poolIps(ips []*apiv1.IP, poolId string) []*apiv1.IP {
+	var pool []*apiv1.IP
+	for _, ip := range ips {
+		if slices.Contains(ip.Tags, labelTag(tagPoolID, poolId)) {
+			pool = append(pool, ip)
+		}
+	}
+	slices.SortStableFunc(pool, func(a, b *apiv1.IP) int {
+		if c := a.CreatedAt.AsTime().Compare(b.CreatedAt.AsTime()); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return pool
+}
+
+// poolIpName names the address at the given position of the pool, positions start at 1.
+func poolIpName(prefix string, position int) string {
+	return fmt.Sprintf("%s-%d", prefix, position)
+}
+
+// nextPoolIpNames returns count names for new addresses, filling the positions of addresses released outside of terraform first.
+func nextPoolIpNames(prefix string, existing []*apiv1.IP, count int) []string {
+	var names []string
+	for position := 1; len(names) < count; position++ {
+		name := poolIpName(prefix, position)
+		if !slices.ContainsFunc(existing, func(ip *apiv1.IP) bool { return ip.Name == name }) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -1,6 +1,13 @@
 package ipaddress
 
 import (
+	"context"
+	"fmt"
+	"net/netip"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	apiv1 "github.com/metal-stack-cloud/api/go/api/v1"
 )
@@ -8,46 +15,267 @@ import (
 // PublicIpListDataSourceModel describes the data source data model.
 type PublicIpListDataSourceModel struct {
 	ContentId types.String    `tfsdk:"id"`
+	Project   types.String    `tfsdk:"project"`
+	Name      types.String    `tfsdk:"name"`
+	Type      types.String    `tfsdk:"type"`
+	Tags      []types.String  `tfsdk:"tags"`
+	Network   types.String    `tfsdk:"network"`
 	Items     []publicIpModel `tfsdk:"items"`
 }
 
 type publicIpModel struct {
+	Uuid          types.String            `tfsdk:"id"`
+	Ip            types.String            `tfsdk:"ip"`
+	Name          types.String            `tfsdk:"name"`
+	Description   types.String            `tfsdk:"description"`
+	Network       types.String            `tfsdk:"network"`
+	Project       types.String            `tfsdk:"project"`
+	Type          types.String            `tfsdk:"type"`
+	AddressFamily types.String            `tfsdk:"address_family"`
+	Tags          []types.String          `tfsdk:"tags"`
+	Labels        map[string]types.String `tfsdk:"labels"`
+	CreatedAt     types.String            `tfsdk:"created_at"`
+	UpdatedAt     types.String            `tfsdk:"updated_at"`
+}
+
+// publicIpResourceModel only holds the tags and labels managed by terraform, see withIpFromApi.
+type publicIpResourceModel struct {
+	Uuid            types.String            `tfsdk:"id"`
+	Ip              types.String            `tfsdk:"ip"`
+	Name            types.String            `tfsdk:"name"`
+	Description     types.String            `tfsdk:"description"`
+	Network         types.String            `tfsdk:"network"`
+	Project         types.String            `tfsdk:"project"`
+	Type            types.String            `tfsdk:"type"`
+	AddressFamily   types.String            `tfsdk:"address_family"`
+	Tags            []types.String          `tfsdk:"tags"`
+	Labels          map[string]types.String `tfsdk:"labels"`
+	EffectiveLabels map[string]types.String `tfsdk:"effective_labels"`
+	ForceDestroy    types.Bool              `tfsdk:"force_destroy"`
+	CreatedAt       types.String            `tfsdk:"created_at"`
+	UpdatedAt       types.String            `tfsdk:"updated_at"`
+}
+
+// publicIpResourceModelV0 is the state of a public IP before labels were introduced.
+type publicIpResourceModelV0 struct {
 	Uuid        types.String   `tfsdk:"id"`
 	Ip          types.String   `tfsdk:"ip"`
 	Name        types.String   `tfsdk:"name"`
 	Description types.String   `tfsdk:"description"`
 	Network     types.String   `tfsdk:"network"`
 	Project     types.String   `tfsdk:"project"`
-	Type        types.String   `tfsdk:"type"` // TODO: make enum; unspecified, ephemeral, static
+	Type        types.String   `tfsdk:"type"`
 	Tags        []types.String `tfsdk:"tags"`
 	CreatedAt   types.String   `tfsdk:"created_at"`
 	UpdatedAt   types.String   `tfsdk:"updated_at"`
 }
 
-func publicIpFromApi(ip *apiv1.IP) publicIpModel {
-	var ipType string
-	switch ip.Type {
+// upgrade drops the tags, which were never managed by terraform, so they do not show up in a diff.
+func (m publicIpResourceModelV0) upgrade() publicIpResourceModel {
+	return publicIpResourceModel{
+		Uuid:          m.Uuid,
+		Ip:            m.Ip,
+		Name:          m.Name,
+		Description:   m.Description,
+		Network:       m.Network,
+		Project:       m.Project,
+		Type:          m.Type,
+		AddressFamily: types.StringValue(addressFamilyOf(m.Ip.ValueString())),
+		ForceDestroy:  types.BoolValue(false),
+		CreatedAt:     m.CreatedAt,
+		UpdatedAt:     m.UpdatedAt,
+	}
+}
+
+const (
+	ipTypeEphemeral   = "ephemeral"
+	ipTypeStatic      = "static"
+	ipTypeUnspecified = "unspecified"
+)
+
+func ipTypeToString(t apiv1.IPType) string {
+	switch t {
 	case apiv1.IPType_IP_TYPE_STATIC:
-		ipType = "static"
+		return ipTypeStatic
 	case apiv1.IPType_IP_TYPE_EPHEMERAL:
-		ipType = "ephemeral"
+		return ipTypeEphemeral
 	case apiv1.IPType_IP_TYPE_UNSPECIFIED:
-		ipType = "unspecified"
+		return ipTypeUnspecified
+	}
+	return ""
+}
+
+const (
+	addressFamilyV4 = "ipv4"
+	addressFamilyV6 = "ipv6"
+)
+
+// addressFamilyFromString returns nil if the api should pick the address family.
+func addressFamilyFromString(family string) (*apiv1.IPAddressFamily, error) {
+	switch family {
+	case addressFamilyV4:
+		return apiv1.IPAddressFamily_IP_ADDRESS_FAMILY_V4.Enum(), nil
+	case addressFamilyV6:
+		return apiv1.IPAddressFamily_IP_ADDRESS_FAMILY_V6.Enum(), nil
+	case "":
+		return nil, nil
+	}
+	return nil, fmt.Errorf("address family %q is invalid, must be either %q or %q", family, addressFamilyV4, addressFamilyV6)
+}
+
+// addressFamilyOf derives the address family from the address, the api does not return it.
+func addressFamilyOf(ip string) string {
+	addr, err := netip.ParseAddr(ip)
+	switch {
+	case err != nil:
+		return ""
+	case addr.Is4() || addr.Is4In6():
+		return addressFamilyV4
+	default:
+		return addressFamilyV6
+	}
+}
+
+// ipTypeFromString is the inverse of ipTypeToString, an empty type is unspecified and lets the api decide.
+func ipTypeFromString(t string) (apiv1.IPType, error) {
+	switch t {
+	case ipTypeStatic:
+		return apiv1.IPType_IP_TYPE_STATIC, nil
+	case ipTypeEphemeral:
+		return apiv1.IPType_IP_TYPE_EPHEMERAL, nil
+	case ipTypeUnspecified, "":
+		return apiv1.IPType_IP_TYPE_UNSPECIFIED, nil
 	}
+	return apiv1.IPType_IP_TYPE_UNSPECIFIED, fmt.Errorf("ip type %q is invalid, must be either %q or %q", t, ipTypeStatic, ipTypeEphemeral)
+}
+
+func publicIpFromApi(ip *apiv1.IP) publicIpModel {
 	tags := make([]types.String, len(ip.Tags))
 	for i, tag := range ip.Tags {
 		tags[i] = types.StringValue(tag)
 	}
+	labels := map[string]types.String{}
+	for key, value := range labelsFromTags(ip.Tags) {
+		labels[key] = types.StringValue(value)
+	}
 	return publicIpModel{
-		Uuid:        types.StringValue(ip.Uuid),
-		Ip:          types.StringValue(ip.Ip),
-		Name:        types.StringValue(ip.Name),
-		Description: types.StringValue(ip.Description),
-		Network:     types.StringValue(ip.Network),
-		Project:     types.StringValue(ip.Project),
-		Type:        types.StringValue(ipType),
-		Tags:        tags,
-		CreatedAt:   types.StringValue(ip.CreatedAt.AsTime().String()),
-		UpdatedAt:   types.StringValue(ip.UpdatedAt.AsTime().String()),
+		Uuid:          types.StringValue(ip.Uuid),
+		Ip:            types.StringValue(ip.Ip),
+		Name:          types.StringValue(ip.Name),
+		Description:   types.StringValue(ip.Description),
+		Network:       types.StringValue(ip.Network),
+		Project:       types.StringValue(ip.Project),
+		Type:          types.StringValue(ipTypeToString(ip.Type)),
+		AddressFamily: types.StringValue(addressFamilyOf(ip.Ip)),
+		Tags:          tags,
+		Labels:        labels,
+		CreatedAt:     types.StringValue(ip.CreatedAt.AsTime().String()),
+		UpdatedAt:     types.StringValue(ip.UpdatedAt.AsTime().String()),
+	}
+}
+
+// withIpFromApi sets all attributes of the IP, but only keeps the tags and labels which are already managed by the model.
+// Tags set by others, e.g. the cloud controller manager, never show up in a diff.
+func (m *publicIpResourceModel) withIpFromApi(ip *apiv1.IP) {
+	all := publicIpFromApi(ip)
+	m.Uuid = all.Uuid
+	m.Ip = all.Ip
+	m.Name = all.Name
+	m.Description = all.Description
+	m.Network = all.Network
+	m.Project = all.Project
+	m.Type = all.Type
+	m.AddressFamily = all.AddressFamily
+	m.CreatedAt = all.CreatedAt
+	m.UpdatedAt = all.UpdatedAt
+
+	if m.Tags != nil {
+		var managed []types.String
+		for _, tag := range ip.Tags {
+			if slices.Contains(m.Tags, types.StringValue(tag)) {
+				managed = append(managed, types.StringValue(tag))
+			}
+		}
+		m.Tags = append([]types.String{}, managed...)
 	}
+	m.Labels = managedLabels(m.Labels, all.Labels)
+	m.EffectiveLabels = managedLabels(m.EffectiveLabels, all.Labels)
+}
+
+func managedLabels(managed, all map[string]types.String) map[string]types.String {
+	if managed == nil {
+		return nil
+	}
+	result := map[string]types.String{}
+	for key := range managed {
+		if value, ok := all[key]; ok {
+			result[key] = value
+		}
+	}
+	return result
+}
+
+type publicIpPoolModel struct {
+	Uuid         types.String   `tfsdk:"id"`
+	Project      types.String   `tfsdk:"project"`
+	NamePrefix   types.String   `tfsdk:"name_prefix"`
+	Size         types.Int64    `tfsdk:"size"`
+	Type         types.String   `tfsdk:"type"`
+	Tags         []types.String `tfsdk:"tags"`
+	ForceDestroy types.Bool     `tfsdk:"force_destroy"`
+	// Addresses and Ips are lists instead of slices, they are unknown in plans growing the pool.
+	Addresses types.List `tfsdk:"addresses"`
+	Ips       types.List `tfsdk:"ips"`
+}
+
+type publicIpPoolAddressModel struct {
+	Uuid types.String `tfsdk:"id"`
+	Ip   types.String `tfsdk:"ip"`
+	Name types.String `tfsdk:"name"`
+}
+
+var publicIpPoolAddressType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"id":   types.StringType,
+		"ip":   types.StringType,
+		"name": types.StringType,
+	},
+}
+
+// withPoolIpsFromApi sets the addresses of the pool and only keeps the tags and type all of them still have.
+func (m *publicIpPoolModel) withPoolIpsFromApi(ctx context.Context, ips []*apiv1.IP) diag.Diagnostics {
+	addresses := make([]publicIpPoolAddressModel, 0, len(ips))
+	plainIps := make([]string, 0, len(ips))
+	for _, ip := range ips {
+		addresses = append(addresses, publicIpPoolAddressModel{
+			Uuid: types.StringValue(ip.Uuid),
+			Ip:   types.StringValue(ip.Ip),
+			Name: types.StringValue(ip.Name),
+		})
+		plainIps = append(plainIps, ip.Ip)
+	}
+
+	m.Size = types.Int64Value(int64(len(ips)))
+	if m.Tags != nil {
+		managed := []types.String{}
+		for _, tag := range m.Tags {
+			if !slices.ContainsFunc(ips, func(ip *apiv1.IP) bool { return !slices.Contains(ip.Tags, tag.ValueString()) }) {
+				managed = append(managed, tag)
+			}
+		}
+		m.Tags = managed
+	}
+	for _, ip := range ips {
+		if ipTypeToString(ip.Type) != m.Type.ValueString() {
+			m.Type = types.StringValue(ipTypeToString(ip.Type))
+			break
+		}
+	}
+
+	var diags, d diag.Diagnostics
+	m.Addresses, d = types.ListValueFrom(ctx, publicIpPoolAddressType, addresses)
+	diags.Append(d...)
+	m.Ips, d = types.ListValueFrom(ctx, types.StringType, plainIps)
+	diags.Append(d...)
+	return diags
 }
@@ -0,0 +1,317 @@
+package ipaddress
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	apiv1 "github.com/metal-stack-cloud/api/go/api/v1"
+	"github.com/metal-stack-cloud/terraform-provider-metal/internal/session"
+)
+
+// maxPoolSize keeps the generated names within the 32 characters the api allows.
+const maxPoolSize = 999
+
+var (
+	_ resource.Resource               = &PublicIpPoolResource{}
+	_ resource.ResourceWithConfigure  = &PublicIpPoolResource{}
+	_ resource.ResourceWithModifyPlan = &PublicIpPoolResource{}
+)
+
+func NewPublicIpPoolResource() resource.Resource {
+	return &PublicIpPoolResource{}
+}
+
+// PublicIpPoolResource manages a number of equal public IP addresses, which are found by their pool tag.
+type PublicIpPoolResource struct {
+	session *session.Session
+}
+
+// Metadata implements resource.Resource.
+func (*PublicIpPoolResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_public_ip_pool"
+}
+
+// Schema implements resource.Resource.
+func (*PublicIpPoolResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: publicIpPoolResourceAttributes(),
+		MarkdownDescription: "Allocates `size` public IP addresses at once, e.g. for edge clusters needing many static addresses. \n" +
+			"Resizing the pool allocates or releases addresses, the newest addresses are released first and the order of `addresses` is stable. \n" +
+			"The addresses are tagged with the pool ID and the provider `default_labels`, they should not be managed by `metal_public_ip` as well. \n" +
+			"If the pool cannot be created completely, the addresses allocated so far are released again. " +
+			"If growing the pool fails, the allocated addresses are kept and the next apply allocates the rest. \n" +
+			"Required permissions: `IP *`.",
+	}
+}
+
+// Configure implements resource.ResourceWithConfigure.
+func (p *PublicIpPoolResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	session, ok := req.ProviderData.(*session.Session)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *session.Session, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	p.session = session
+}
+
+// Create implements resource.Resource.
+func (p *PublicIpPoolResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan publicIpPoolModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Project.ValueString() == "" {
+		plan.Project = types.StringValue(p.session.Project)
+	}
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to generate pool id", err.Error())
+		return
+	}
+	plan.Uuid = types.StringValue(id)
+
+	allocated, err := p.allocate(ctx, plan, nil, int(plan.Size.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to allocate IP addresses", err.Error())
+		// a failed create taints the pool, so the addresses allocated so far would only be replaced by new ones
+		slices.Reverse(allocated)
+		resp.Diagnostics.Append(p.release(ctx, allocated, true)...)
+		return
+	}
+	resp.Diagnostics.Append(plan.withPoolIpsFromApi(ctx, poolIps(allocated, id))...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read implements resource.Resource.
+func (p *PublicIpPoolResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state publicIpPoolModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ips, err := listIps(ctx, p.session, state.Project.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to list IP addresses", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(state.withPoolIpsFromApi(ctx, poolIps(ips, state.Uuid.ValueString()))...)
+	if state.ForceDestroy.IsNull() {
+		state.ForceDestroy = types.BoolValue(false)
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// Update implements resource.Resource.
+func (p *PublicIpPoolResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state, plan publicIpPoolModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ips, err := listIpsUncached(ctx, p.session, state.Project.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to list IP addresses", err.Error())
+		return
+	}
+	current := poolIps(ips, state.Uuid.ValueString())
+	size := int(plan.Size.ValueInt64())
+
+	if size < len(current) {
+		released := slices.Clone(current[size:])
+		slices.Reverse(released)
+		resp.Diagnostics.Append(p.release(ctx, released, plan.ForceDestroy.ValueBool())...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		current = current[:size]
+	}
+
+	planType, err := ipTypeFromString(plan.Type.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("type"), "Invalid ip type", err.Error())
+		return
+	}
+	oldTags := stringsFromValues(state.Tags)
+	newTags := stringsFromValues(plan.Tags)
+	labels := p.labels(plan)
+	prefixChanged := plan.NamePrefix != state.NamePrefix
+	updated := make([]*apiv1.IP, 0, size)
+	for i, ip := range current {
+		tags := mergeTags(ip.Tags, oldTags, labels, newTags, labels)
+		if !prefixChanged && ip.Type == planType && slices.Equal(tags, slices.Sorted(slices.Values(ip.Tags))) {
+			updated = append(updated, ip)
+			continue
+		}
+
+		ipUpdate := &apiv1.IP{
+			Uuid:        ip.Uuid,
+			Ip:          ip.Ip,
+			Name:        ip.Name,
+			Description: ip.Description,
+			Network:     ip.Network,
+			Project:     ip.Project,
+			Type:        planType,
+			Tags:        tags,
+		}
+		if prefixChanged {
+			ipUpdate.Name = poolIpName(plan.NamePrefix.ValueString(), i+1)
+		}
+		updateResp, err := p.session.Client.Apiv1().IP().Update(ctx, connect.NewRequest(&apiv1.IPServiceUpdateRequest{
+			Project: ip.Project,
+			Ip:      ipUpdate,
+		}))
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to update IP address", fmt.Sprintf("updating %s failed: %s", ip.Ip, err.Error()))
+			return
+		}
+		updated = append(updated, updateResp.Msg.Ip)
+	}
+
+	allocated, err := p.allocate(ctx, plan, updated, size-len(updated))
+	held := append(updated, allocated...)
+	resp.Diagnostics.Append(plan.withPoolIpsFromApi(ctx, poolIps(held, plan.Uuid.ValueString()))...)
+	if err != nil {
+		// the addresses allocated so far are kept, the smaller size in state lets the next apply allocate the rest
+		resp.Diagnostics.AddError("Failed to allocate IP addresses", err.Error())
+		plan.Size = types.Int64Value(int64(len(held)))
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete implements resource.Resource.
+func (p *PublicIpPoolResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state publicIpPoolModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ips, err := listIpsUncached(ctx, p.session, state.Project.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to list IP addresses", err.Error())
+		return
+	}
+	released := poolIps(ips, state.Uuid.ValueString())
+	slices.Reverse(released)
+	resp.Diagnostics.Append(p.release(ctx, released, state.ForceDestroy.ValueBool())...)
+}
+
+// ModifyPlan implements resource.ResourceWithModifyPlan.
+func (p *PublicIpPoolResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		p.session.CheckPlan(ctx, req, resp)
+		return
+	}
+
+	// tags may still be unknown, so only the attributes needed are read
+	var size types.Int64
+	var planPrefix, statePrefix types.String
+	var stateAddresses, stateIps types.List
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("size"), &size)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("name_prefix"), &planPrefix)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("name_prefix"), &statePrefix)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("addresses"), &stateAddresses)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("ips"), &stateIps)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the remaining addresses are known unless the pool grows or gets renamed
+	if !size.IsUnknown() && !planPrefix.IsUnknown() && planPrefix == statePrefix && size.ValueInt64() <= int64(len(stateAddresses.Elements())) {
+		addresses, diags := types.ListValue(publicIpPoolAddressType, stateAddresses.Elements()[:size.ValueInt64()])
+		resp.Diagnostics.Append(diags...)
+		ips, diags := types.ListValue(types.StringType, stateIps.Elements()[:size.ValueInt64()])
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("addresses"), addresses)...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("ips"), ips)...)
+	}
+
+	p.session.CheckPlan(ctx, req, resp)
+}
+
+// allocate adds count addresses to the pool, named by the positions existing addresses do not use.
+// The addresses allocated before an error are returned as well.
+func (p *PublicIpPoolResource) allocate(ctx context.Context, pool publicIpPoolModel, existing []*apiv1.IP, count int) ([]*apiv1.IP, error) {
+	planType, err := ipTypeFromString(pool.Type.ValueString())
+	if err != nil {
+		return nil, err
+	}
+	tags := mergeTags(nil, nil, nil, stringsFromValues(pool.Tags), p.labels(pool))
+
+	var allocated []*apiv1.IP
+	for _, name := range nextPoolIpNames(pool.NamePrefix.ValueString(), existing, count) {
+		allocateResp, err := p.session.Client.Apiv1().IP().Allocate(ctx, connect.NewRequest(&apiv1.IPServiceAllocateRequest{
+			Project: pool.Project.ValueString(),
+			Name:    name,
+			Tags:    tags,
+			Static:  planType == apiv1.IPType_IP_TYPE_STATIC,
+		}))
+		if err != nil {
+			return allocated, fmt.Errorf("allocating %s failed after %d of %d addresses: %w", name, len(allocated), count, err)
+		}
+		allocated = append(allocated, allocateResp.Msg.Ip)
+	}
+	return allocated, nil
+}
+
+// labels are set on every address of the pool, the provider default labels and the pool ID, which cannot be overridden.
+func (p *PublicIpPoolResource) labels(pool publicIpPoolModel) map[string]string {
+	return mergeLabels(p.session.DefaultLabels, map[string]string{tagPoolID: pool.Uuid.ValueString()})
+}
+
+// release deletes the addresses in the given order. Unless forced, nothing is deleted if any of them is still in use.
+func (p *PublicIpPoolResource) release(ctx context.Context, ips []*apiv1.IP, force bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if !force {
+		for _, ip := range ips {
+			if consumers := ipConsumers(ip.Tags); len(consumers) > 0 {
+				diags.AddError(
+					"IP address is in use",
+					fmt.Sprintf("The IP address %s of the pool is still used by %s. Release it there first or set force_destroy = true to release it anyway.",
+						ip.Ip, strings.Join(consumers, ", ")),
+				)
+			}
+		}
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	for _, ip := range ips {
+		_, err := p.session.Client.Apiv1().IP().Delete(ctx, connect.NewRequest(&apiv1.IPServiceDeleteRequest{
+			Uuid:    ip.Uuid,
+			Project: ip.Project,
+		}))
+		if err != nil {
+			diags.AddError("Failed to release IP address", fmt.Sprintf("releasing %s failed: %s", ip.Ip, err.Error()))
+			return diags
+		}
+	}
+	return diags
+}
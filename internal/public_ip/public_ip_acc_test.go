@@ -130,6 +130,39 @@ resource "metal_public_ip" "ip" {
 }
 `
 
+func TestAccPublicIPPool(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccPublicIpPool, 3),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("metal_public_ip_pool.pool", "ips.#", "3"),
+					resource.TestCheckResourceAttr("metal_public_ip_pool.pool", "addresses.0.name", "acc-pool-1"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccPublicIpPool, 1),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{plancheck.ExpectResourceAction("metal_public_ip_pool.pool", plancheck.ResourceActionUpdate)},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("metal_public_ip_pool.pool", "ips.#", "1"),
+					resource.TestCheckResourceAttr("metal_public_ip_pool.pool", "addresses.0.name", "acc-pool-1"),
+				),
+			},
+		},
+	})
+}
+
+const testAccPublicIpPool = `
+resource "metal_public_ip_pool" "pool" {
+	name_prefix = "acc-pool"
+	size        = %d
+	type        = "ephemeral"
+}
+`
+
 func testCheckResourceAttrResolve(name, key string, derefValue func() string) resource.TestCheckFunc {
 	return resource.TestCheckResourceAttrWith(name, key, func(value string) error {
 		v := derefValue()
@@ -1,8 +1,10 @@
 package ipaddress
 
 import (
+	"context"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	apiv1 "github.com/metal-stack-cloud/api/go/api/v1"
 	assert "github.com/stretchr/testify/assert"
@@ -30,17 +32,19 @@ func Test_publicIpFromApi(t *testing.T) {
 		},
 	}
 	want := publicIpModel{
-		Uuid:        basetypes.NewStringValue("1"),
-		Ip:          basetypes.NewStringValue("1.2.3"),
-		Name:        basetypes.NewStringValue("ip"),
-		Description: basetypes.NewStringValue("Test ip"),
-		Network:     basetypes.NewStringValue("internet"),
-		Project:     basetypes.NewStringValue("default-project"),
-		Type:        basetypes.NewStringValue("ephemeral"),
+		Uuid:          basetypes.NewStringValue("1"),
+		Ip:            basetypes.NewStringValue("1.2.3"),
+		Name:          basetypes.NewStringValue("ip"),
+		Description:   basetypes.NewStringValue("Test ip"),
+		Network:       basetypes.NewStringValue("internet"),
+		Project:       basetypes.NewStringValue("default-project"),
+		Type:          basetypes.NewStringValue("ephemeral"),
+		AddressFamily: basetypes.NewStringValue(""),
 		Tags: []basetypes.StringValue{
 			basetypes.NewStringValue("tag-1"),
 			basetypes.NewStringValue("tag-2"),
 		},
+		Labels:    map[string]basetypes.StringValue{},
 		CreatedAt: basetypes.NewStringValue("2024-02-08 08:48:20 +0000 UTC"),
 		UpdatedAt: basetypes.NewStringValue("2024-06-09 11:34:37 +0000 UTC"),
 	}
@@ -48,3 +52,207 @@ func Test_publicIpFromApi(t *testing.T) {
 	ipModel := publicIpFromApi(ip)
 	assert.Equal(t, want, ipModel)
 }
+
+func Test_filterIps(t *testing.T) {
+	ingress := &apiv1.IP{Uuid: "1", Ip: "212.34.83.12", Name: "ingress", Network: "internet", Type: apiv1.IPType_IP_TYPE_STATIC, Tags: []string{"team=a", "prod"}}
+	egress := &apiv1.IP{Uuid: "2", Ip: "212.34.83.13", Name: "egress", Network: "internet", Type: apiv1.IPType_IP_TYPE_EPHEMERAL, Tags: []string{"prod"}}
+	other := &apiv1.IP{Uuid: "3", Ip: "10.0.0.1", Name: "ingress", Network: "internal", Type: apiv1.IPType_IP_TYPE_EPHEMERAL}
+	ips := []*apiv1.IP{ingress, egress, other}
+
+	assert.Equal(t, ips, filterIps(ips, "", "", "", nil))
+	assert.Equal(t, []*apiv1.IP{ingress, other}, filterIps(ips, "ingress", "", "", nil))
+	assert.Equal(t, []*apiv1.IP{egress, other}, filterIps(ips, "", "ephemeral", "", nil))
+	assert.Equal(t, []*apiv1.IP{other}, filterIps(ips, "", "", "internal", nil))
+	assert.Equal(t, []*apiv1.IP{ingress, egress}, filterIps(ips, "", "", "", []string{"prod"}))
+	assert.Equal(t, []*apiv1.IP{ingress}, filterIps(ips, "", "", "", []string{"prod", "team=a"}))
+	assert.Empty(t, filterIps(ips, "egress", "static", "", nil))
+}
+
+func Test_findIp(t *testing.T) {
+	ingress := &apiv1.IP{Uuid: "1", Ip: "212.34.83.12", Name: "ingress"}
+	egress := &apiv1.IP{Uuid: "2", Ip: "212.34.83.13", Name: "egress"}
+	duplicate := &apiv1.IP{Uuid: "3", Ip: "212.34.83.14", Name: "ingress"}
+	ips := []*apiv1.IP{ingress, egress, duplicate}
+
+	found, err := findIp(ips, "2", "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, egress, found)
+
+	found, err = findIp(ips, "", "", "212.34.83.14")
+	assert.NoError(t, err)
+	assert.Equal(t, duplicate, found)
+
+	_, err = findIp(ips, "", "ingress", "")
+	assert.EqualError(t, err, "2 public ips match, look them up by id instead: 1, 3")
+
+	_, err = findIp(ips, "", "unknown", "")
+	assert.EqualError(t, err, `no public ip found with id "", name "unknown" or address ""`)
+}
+
+func Test_mergeTags(t *testing.T) {
+	current := []string{"prod", "team=a", "cluster.metal-stack.io/id/namespace/service=abc/default/ingress"}
+
+	tags := mergeTags(current, []string{"prod"}, map[string]string{"team": "a"}, []string{"staging"}, map[string]string{"team": "b"})
+	assert.Equal(t, []string{"cluster.metal-stack.io/id/namespace/service=abc/default/ingress", "staging", "team=b"}, tags)
+
+	tags = mergeTags(current, nil, nil, nil, map[string]string{"owner": "ops"})
+	assert.Equal(t, []string{"cluster.metal-stack.io/id/namespace/service=abc/default/ingress", "owner=ops", "prod", "team=a"}, tags)
+
+	tags = mergeTags(nil, nil, nil, []string{"prod"}, map[string]string{"team": "a"})
+	assert.Equal(t, []string{"prod", "team=a"}, tags)
+}
+
+func Test_mergeLabels(t *testing.T) {
+	assert.Equal(t, map[string]string{}, mergeLabels(nil, nil))
+	assert.Equal(t, map[string]string{"team": "b", "cost-center": "42"}, mergeLabels(map[string]string{"team": "a", "cost-center": "42"}, map[string]string{"team": "b"}))
+}
+
+func Test_withIpFromApi(t *testing.T) {
+	ip := &apiv1.IP{
+		Uuid:    "1",
+		Ip:      "212.34.83.12",
+		Name:    "ingress",
+		Network: "internet",
+		Project: "default-project",
+		Type:    apiv1.IPType_IP_TYPE_STATIC,
+		Tags:    []string{"prod", "unmanaged", "team=a", "cluster.metal-stack.io/id/namespace/service=abc/default/ingress"},
+		CreatedAt: &timestamppb.Timestamp{
+			Seconds: int64(1707382100),
+		},
+		UpdatedAt: &timestamppb.Timestamp{
+			Seconds: int64(1717932877),
+		},
+	}
+
+	model := publicIpResourceModel{
+		Tags:            []basetypes.StringValue{basetypes.NewStringValue("prod"), basetypes.NewStringValue("removed")},
+		Labels:          map[string]basetypes.StringValue{"team": basetypes.NewStringValue("b")},
+		EffectiveLabels: map[string]basetypes.StringValue{"team": basetypes.NewStringValue("b")},
+	}
+	model.withIpFromApi(ip)
+	assert.Equal(t, basetypes.NewStringValue("static"), model.Type)
+	assert.Equal(t, []basetypes.StringValue{basetypes.NewStringValue("prod")}, model.Tags)
+	assert.Equal(t, map[string]basetypes.StringValue{"team": basetypes.NewStringValue("a")}, model.Labels)
+	assert.Equal(t, map[string]basetypes.StringValue{"team": basetypes.NewStringValue("a")}, model.EffectiveLabels)
+
+	unmanaged := publicIpResourceModel{}
+	unmanaged.withIpFromApi(ip)
+	assert.Nil(t, unmanaged.Tags)
+	assert.Nil(t, unmanaged.Labels)
+	assert.Nil(t, unmanaged.EffectiveLabels)
+}
+
+func Test_publicIpResourceModelV0_upgrade(t *testing.T) {
+	prior := publicIpResourceModelV0{
+		Uuid:        basetypes.NewStringValue("1"),
+		Ip:          basetypes.NewStringValue("212.34.83.12"),
+		Name:        basetypes.NewStringValue("ingress"),
+		Description: basetypes.NewStringValue(""),
+		Network:     basetypes.NewStringValue("internet"),
+		Project:     basetypes.NewStringValue("default-project"),
+		Type:        basetypes.NewStringValue("static"),
+		Tags:        []basetypes.StringValue{basetypes.NewStringValue("cluster.metal-stack.io/id=abc")},
+		CreatedAt:   basetypes.NewStringValue("2024-02-08 08:48:20 +0000 UTC"),
+		UpdatedAt:   basetypes.NewStringValue("2024-06-09 11:34:37 +0000 UTC"),
+	}
+	want := publicIpResourceModel{
+		Uuid:          basetypes.NewStringValue("1"),
+		Ip:            basetypes.NewStringValue("212.34.83.12"),
+		Name:          basetypes.NewStringValue("ingress"),
+		Description:   basetypes.NewStringValue(""),
+		Network:       basetypes.NewStringValue("internet"),
+		Project:       basetypes.NewStringValue("default-project"),
+		Type:          basetypes.NewStringValue("static"),
+		AddressFamily: basetypes.NewStringValue("ipv4"),
+		ForceDestroy:  basetypes.NewBoolValue(false),
+		CreatedAt:     basetypes.NewStringValue("2024-02-08 08:48:20 +0000 UTC"),
+		UpdatedAt:     basetypes.NewStringValue("2024-06-09 11:34:37 +0000 UTC"),
+	}
+
+	assert.Equal(t, want, prior.upgrade())
+}
+
+func Test_ipTypeFromString(t *testing.T) {
+	for _, ipType := range []apiv1.IPType{apiv1.IPType_IP_TYPE_STATIC, apiv1.IPType_IP_TYPE_EPHEMERAL, apiv1.IPType_IP_TYPE_UNSPECIFIED} {
+		parsed, err := ipTypeFromString(ipTypeToString(ipType))
+		assert.NoError(t, err)
+		assert.Equal(t, ipType, parsed)
+	}
+
+	parsed, err := ipTypeFromString("")
+	assert.NoError(t, err)
+	assert.Equal(t, apiv1.IPType_IP_TYPE_UNSPECIFIED, parsed)
+
+	_, err = ipTypeFromString("dynamic")
+	assert.EqualError(t, err, `ip type "dynamic" is invalid, must be either "static" or "ephemeral"`)
+}
+
+func Test_ipConsumers(t *testing.T) {
+	assert.Empty(t, ipConsumers([]string{"prod", "team=a"}))
+	assert.Equal(t, []string{
+		`cluster "abc"`,
+		`service "default/ingress" of cluster "abc"`,
+	}, ipConsumers([]string{"prod", "cluster.metal-stack.io/id/namespace/service=abc/default/ingress", "cluster.metal-stack.io/id=abc"}))
+	assert.Equal(t, []string{
+		`service "default/ingress" of cluster "abc"`,
+		`service "kube-system/dns" of cluster "abc"`,
+	}, ipConsumers([]string{"cluster.metal-stack.io/id/namespace/service=abc/kube-system/dns", "cluster.metal-stack.io/id/namespace/service=abc/default/ingress"}))
+}
+
+func Test_addressFamily(t *testing.T) {
+	assert.Equal(t, "ipv4", addressFamilyOf("212.34.83.12"))
+	assert.Equal(t, "ipv6", addressFamilyOf("2a02:c00:20::1"))
+	assert.Equal(t, "", addressFamilyOf("not-an-ip"))
+
+	family, err := addressFamilyFromString("ipv6")
+	assert.NoError(t, err)
+	assert.Equal(t, apiv1.IPAddressFamily_IP_ADDRESS_FAMILY_V6, *family)
+
+	family, err = addressFamilyFromString("")
+	assert.NoError(t, err)
+	assert.Nil(t, family)
+
+	_, err = addressFamilyFromString("ipx")
+	assert.EqualError(t, err, `address family "ipx" is invalid, must be either "ipv4" or "ipv6"`)
+}
+
+func Test_poolIps(t *testing.T) {
+	first := &apiv1.IP{Uuid: "1", Name: "edge-1", Tags: []string{"terraform.metal-stack.cloud/public-ip-pool=p"}, CreatedAt: &timestamppb.Timestamp{Seconds: int64(1707382100)}}
+	second := &apiv1.IP{Uuid: "2", Name: "edge-2", Tags: []string{"terraform.metal-stack.cloud/public-ip-pool=p"}, CreatedAt: &timestamppb.Timestamp{Seconds: int64(1707382100)}}
+	newest := &apiv1.IP{Uuid: "3", Name: "edge-3", Tags: []string{"prod", "terraform.metal-stack.cloud/public-ip-pool=p"}, CreatedAt: &timestamppb.Timestamp{Seconds: int64(1717932877)}}
+	other := &apiv1.IP{Uuid: "4", Name: "edge-1", Tags: []string{"terraform.metal-stack.cloud/public-ip-pool=q"}, CreatedAt: &timestamppb.Timestamp{Seconds: int64(1707382100)}}
+
+	assert.Equal(t, []*apiv1.IP{first, second, newest}, poolIps([]*apiv1.IP{newest, other, second, first}, "p"))
+	assert.Empty(t, poolIps([]*apiv1.IP{first, other}, "r"))
+}
+
+func Test_nextPoolIpNames(t *testing.T) {
+	assert.Equal(t, []string{"edge-1", "edge-2"}, nextPoolIpNames("edge", nil, 2))
+	assert.Empty(t, nextPoolIpNames("edge", nil, 0))
+
+	existing := []*apiv1.IP{{Name: "edge-1"}, {Name: "edge-3"}}
+	assert.Equal(t, []string{"edge-2", "edge-4", "edge-5"}, nextPoolIpNames("edge", existing, 3))
+}
+
+func Test_withPoolIpsFromApi(t *testing.T) {
+	ips := []*apiv1.IP{
+		{Uuid: "1", Ip: "212.34.83.12", Name: "edge-1", Type: apiv1.IPType_IP_TYPE_STATIC, Tags: []string{"prod", "edge"}},
+		{Uuid: "2", Ip: "212.34.83.13", Name: "edge-2", Type: apiv1.IPType_IP_TYPE_STATIC, Tags: []string{"prod"}},
+	}
+	model := publicIpPoolModel{
+		Size: basetypes.NewInt64Value(3),
+		Type: basetypes.NewStringValue("static"),
+		Tags: []basetypes.StringValue{basetypes.NewStringValue("prod"), basetypes.NewStringValue("edge")},
+	}
+
+	diags := model.withPoolIpsFromApi(context.Background(), ips)
+	assert.False(t, diags.HasError())
+	assert.Equal(t, basetypes.NewInt64Value(2), model.Size)
+	assert.Equal(t, basetypes.NewStringValue("static"), model.Type)
+	assert.Equal(t, []basetypes.StringValue{basetypes.NewStringValue("prod")}, model.Tags)
+	assert.Equal(t, basetypes.NewListValueMust(basetypes.StringType{}, []attr.Value{
+		basetypes.NewStringValue("212.34.83.12"),
+		basetypes.NewStringValue("212.34.83.13"),
+	}), model.Ips)
+	assert.Len(t, model.Addresses.Elements(), 2)
+}
@@ -3,20 +3,28 @@ package ipaddress
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
+	"strings"
 
 	"connectrpc.com/connect"
 	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	apiv1 "github.com/metal-stack-cloud/api/go/api/v1"
 	"github.com/metal-stack-cloud/terraform-provider-metal/internal/session"
 )
 
 var (
-	_ resource.Resource                = &PublicIpResource{}
-	_ resource.ResourceWithConfigure   = &PublicIpResource{}
-	_ resource.ResourceWithImportState = &PublicIpResource{}
+	_ resource.Resource                 = &PublicIpResource{}
+	_ resource.ResourceWithConfigure    = &PublicIpResource{}
+	_ resource.ResourceWithImportState  = &PublicIpResource{}
+	_ resource.ResourceWithModifyPlan   = &PublicIpResource{}
+	_ resource.ResourceWithUpgradeState = &PublicIpResource{}
 )
 
 func NewPublicIpResource() resource.Resource {
@@ -35,11 +43,13 @@ func (*PublicIpResource) Metadata(ctx context.Context, req resource.MetadataRequ
 // Schema implements resource.Resource.
 func (*PublicIpResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:    1,
 		Attributes: publicIpResourceAttributes(),
 		MarkdownDescription: "Each cluster gets an IP automatically provided on the internet gateway for outgoing communication. \n" +
 			"Services get an IP automatically on creation. \n" +
 			"Services and gateway IPs are dynamic by default. \n" +
 			"You can use an IP address in several clusters and locations at the same time. \n" +
+			"Addresses still used by a cluster are not deleted unless `force_destroy` is set. \n" +
 			"Required permissions: `IP *`. Can be imported by ID, name or ip address.",
 	}
 }
@@ -64,7 +74,7 @@ func (ip *PublicIpResource) Configure(ctx context.Context, req resource.Configur
 
 // Create implements resource.Resource.
 func (ip *PublicIpResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var plan publicIpModel
+	var plan publicIpResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -79,59 +89,85 @@ func (ip *PublicIpResource) Create(ctx context.Context, req resource.CreateReque
 	if ipReq.Project == "" {
 		ipReq.Project = ip.session.Project
 	}
-	switch plan.Type.ValueString() {
-	case "ephemeral":
-		ipReq.Static = false
-	case "static":
-		ipReq.Static = true
-	case "unspecified", "": // let the api decide
-	default:
-		resp.Diagnostics.AddError("Invalid ip type", fmt.Sprintf("ip type %q is invalid", plan.Type.ValueString()))
+	ipType, err := ipTypeFromString(plan.Type.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid ip type", err.Error())
 		return
 	}
-	for _, tag := range plan.Tags {
-		ipReq.Tags = append(ipReq.Tags, tag.ValueString())
+	ipReq.Static = ipType == apiv1.IPType_IP_TYPE_STATIC
+	ipReq.Network = plan.Network.ValueString()
+	ipReq.AddressFamily, err = addressFamilyFromString(plan.AddressFamily.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("address_family"), "Invalid address family", err.Error())
+		return
 	}
+	ipReq.Tags = mergeTags(nil, nil, nil, stringsFromValues(plan.Tags), stringMapFromValues(plan.EffectiveLabels))
 	createdIp, err := ip.session.Client.Apiv1().IP().Allocate(ctx, connect.NewRequest(ipReq))
 	if err != nil {
+		if code := connect.CodeOf(err); (code == connect.CodeInvalidArgument || code == connect.CodeNotFound) && (ipReq.Network != "" || ipReq.AddressFamily != nil) {
+			resp.Diagnostics.AddError(
+				"Failed to allocate IP address",
+				fmt.Sprintf("The API does not offer an address with network %q and address family %q in this project: %s",
+					ipReq.Network, plan.AddressFamily.ValueString(), err.Error()),
+			)
+			return
+		}
 		resp.Diagnostics.AddError("Failed to allocate IP address", err.Error())
 		return
 	}
-	diags = resp.State.Set(ctx, publicIpFromApi(createdIp.Msg.Ip))
+	plan.withIpFromApi(createdIp.Msg.Ip)
+	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 }
 
 // Read implements resource.Resource.
 func (ip *PublicIpResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var state publicIpModel
+	var state publicIpResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	ipResp, err := ip.session.Client.Apiv1().IP().Get(ctx, connect.NewRequest(&apiv1.IPServiceGetRequest{
-		Uuid:    state.Uuid.ValueString(),
-		Project: ip.session.Project,
-	}))
+	project := state.Project.ValueString()
+	if project == "" {
+		project = ip.session.Project
+	}
+	readIp, err := getIp(ctx, ip.session, project, state.Uuid.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to get IP address", err.Error())
 		return
 	}
 
-	diags = resp.State.Set(ctx, publicIpFromApi(ipResp.Msg.Ip))
+	state.withIpFromApi(readIp)
+	if state.ForceDestroy.IsNull() {
+		// imported or created by an older provider version
+		state.ForceDestroy = types.BoolValue(false)
+	}
+	diags = resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
 }
 
 // Update implements resource.Resource.
 func (ip *PublicIpResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var state publicIpModel
+	var state publicIpResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	project := state.Project.ValueString()
+	if project == "" {
+		project = ip.session.Project
+	}
+	// the current tags are needed to keep those terraform does not manage, the cached list might miss the latest ones
+	currentIp, err := getIpUncached(ctx, ip.session, project, state.Uuid.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get IP address", err.Error())
+		return
+	}
+
 	ipUpdate := &apiv1.IP{
 		Uuid:        state.Uuid.ValueString(),
 		Ip:          state.Ip.ValueString(),
@@ -143,22 +179,13 @@ func (ip *PublicIpResource) Update(ctx context.Context, req resource.UpdateReque
 	if ipUpdate.Project == "" {
 		ipUpdate.Project = ip.session.Project
 	}
-	switch state.Type.ValueString() {
-	case "ephemeral":
-		ipUpdate.Type = apiv1.IPType_IP_TYPE_EPHEMERAL
-	case "static":
-		ipUpdate.Type = apiv1.IPType_IP_TYPE_STATIC
-	case "unspecified", "":
-		ipUpdate.Type = apiv1.IPType_IP_TYPE_UNSPECIFIED
-	default:
-		resp.Diagnostics.AddError("Invalid ip type", fmt.Sprintf("ip type %q is invalid", state.Type.ValueString()))
+	ipUpdate.Type, err = ipTypeFromString(state.Type.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid ip type", err.Error())
 		return
 	}
-	for _, tag := range state.Tags {
-		ipUpdate.Tags = append(ipUpdate.Tags, tag.ValueString())
-	}
 
-	var plan publicIpModel
+	var plan publicIpResourceModel
 	diags = req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -173,52 +200,73 @@ func (ip *PublicIpResource) Update(ctx context.Context, req resource.UpdateReque
 	}
 
 	if !plan.Type.IsNull() && plan.Type != state.Type {
-		switch plan.Type.ValueString() {
-		case "ephemeral":
-			if ipUpdate.Type == apiv1.IPType_IP_TYPE_STATIC {
-				resp.Diagnostics.AddError("Cannot update static IPs to ephemeral", "Static IP addresses cannot be declared ephemeral.")
-				return
-			}
-			ipUpdate.Type = apiv1.IPType_IP_TYPE_EPHEMERAL
-		case "static":
-			ipUpdate.Type = apiv1.IPType_IP_TYPE_STATIC
-		case "unspecified", "":
-		default:
-			resp.Diagnostics.AddError("Invalid ip type", fmt.Sprintf("ip type %q is invalid", plan.Type.ValueString()))
+		planType, err := ipTypeFromString(plan.Type.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid ip type", err.Error())
 			return
 		}
-	}
-	if plan.Tags != nil {
-		ipUpdate.Tags = make([]string, len(plan.Tags))
-		for i, tag := range plan.Tags {
-			ipUpdate.Tags[i] = tag.ValueString()
+		// static to ephemeral requires a replacement, see requiresReplaceIfMadeEphemeral
+		if ipUpdate.Type == apiv1.IPType_IP_TYPE_STATIC && planType == apiv1.IPType_IP_TYPE_EPHEMERAL {
+			resp.Diagnostics.AddError("Cannot update static IPs to ephemeral", "Static IP addresses cannot be declared ephemeral.")
+			return
 		}
+		if planType != apiv1.IPType_IP_TYPE_UNSPECIFIED {
+			ipUpdate.Type = planType
+		}
+	}
+	oldTags := stringsFromValues(state.Tags)
+	if plan.Tags == nil {
+		// tags are no longer managed, keep them all
+		oldTags = nil
 	}
+	ipUpdate.Tags = mergeTags(currentIp.Tags, oldTags, stringMapFromValues(state.EffectiveLabels), stringsFromValues(plan.Tags), stringMapFromValues(plan.EffectiveLabels))
 
 	updatedIp, err := ip.session.Client.Apiv1().IP().Update(ctx, connect.NewRequest(&apiv1.IPServiceUpdateRequest{
-		Project: ip.session.Project,
+		Project: ipUpdate.Project,
 		Ip:      ipUpdate,
 	}))
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to update IP address", err.Error())
 		return
 	}
-	diags = resp.State.Set(ctx, publicIpFromApi(updatedIp.Msg.Ip))
+	plan.withIpFromApi(updatedIp.Msg.Ip)
+	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 }
 
 // Delete implements resource.Resource.
 func (ip *PublicIpResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var state publicIpModel
+	var state publicIpResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	project := state.Project.ValueString()
+	if project == "" {
+		project = ip.session.Project
+	}
+	if !state.ForceDestroy.ValueBool() {
+		// ask the api directly, the cached list might not know the latest tags of the cloud controller manager
+		current, err := getIpUncached(ctx, ip.session, project, state.Uuid.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to get IP address", err.Error())
+			return
+		}
+		if consumers := ipConsumers(current.Tags); len(consumers) > 0 {
+			resp.Diagnostics.AddError(
+				"IP address is in use",
+				fmt.Sprintf("The IP address %s is still used by %s. Release it there first or set force_destroy = true to delete it anyway.",
+					current.Ip, strings.Join(consumers, ", ")),
+			)
+			return
+		}
+	}
+
 	_, err := ip.session.Client.Apiv1().IP().Delete(ctx, connect.NewRequest(&apiv1.IPServiceDeleteRequest{
 		Uuid:    state.Uuid.ValueString(),
-		Project: state.Project.ValueString(),
+		Project: project,
 	}))
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to delete IP address", err.Error())
@@ -226,30 +274,107 @@ func (ip *PublicIpResource) Delete(ctx context.Context, req resource.DeleteReque
 	}
 }
 
-// ImportState implements resource.ResourceWithImportState.
-func (ip *PublicIpResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	if _, err := uuid.ParseUUID(req.ID); err == nil {
-		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+// ModifyPlan implements resource.ResourceWithModifyPlan.
+func (ip *PublicIpResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		ip.session.CheckPlan(ctx, req, resp)
 		return
 	}
 
-	listRequestMessage := &apiv1.IPServiceListRequest{
-		Project: ip.session.Project,
+	// effective_labels follow the labels and the provider default_labels, so changed defaults update every address.
+	// They stay unknown as long as the provider is not configured and its default_labels are not known yet.
+	var labels types.Map
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("labels"), &labels)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	clusterList, err := ip.session.Client.Apiv1().IP().List(ctx, connect.NewRequest(listRequestMessage))
-	if err != nil {
-		resp.Diagnostics.AddError("Failed to get all public ips", err.Error())
+	effectiveLabels := types.MapUnknown(types.StringType)
+	if ip.session != nil && !labels.IsUnknown() && !slices.ContainsFunc(slices.Collect(maps.Values(labels.Elements())), attr.Value.IsUnknown) {
+		configured := map[string]string{}
+		resp.Diagnostics.Append(labels.ElementsAs(ctx, &configured, false)...)
+		merged := mergeLabels(ip.session.DefaultLabels, configured)
+		if len(merged) == 0 {
+			effectiveLabels = types.MapNull(types.StringType)
+		} else {
+			var diags diag.Diagnostics
+			effectiveLabels, diags = types.MapValueFrom(ctx, types.StringType, merged)
+			resp.Diagnostics.Append(diags...)
+		}
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("effective_labels"), effectiveLabels)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !req.State.Raw.IsNull() {
+		var stateType, planType types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("type"), &stateType)...)
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("type"), &planType)...)
+		if stateType.ValueString() == ipTypeEphemeral && planType.ValueString() == ipTypeStatic {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("type"),
+				"Ephemeral IP address becomes static",
+				"The address is kept when it is no longer used by a cluster and is only released by destroying this resource. "+
+					"Static addresses cannot be made ephemeral again without replacing them, which allocates a new address.",
+			)
+		}
+	}
+
+	ip.session.CheckPlan(ctx, req, resp)
+}
+
+// UpgradeState implements resource.ResourceWithUpgradeState.
+func (ip *PublicIpResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// tags were computed in version 0 and held every tag of the address, none of them was managed by terraform
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: publicIpResourceAttributesV0(),
+			},
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior publicIpResourceModelV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				resp.Diagnostics.Append(resp.State.Set(ctx, prior.upgrade())...)
+			},
+		},
+	}
+}
+
+// ImportState implements resource.ResourceWithImportState.
+// The ID is the UUID, address or name of the IP, optionally prefixed by its project as in project/id.
+func (ip *PublicIpResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	project, id, found := strings.Cut(req.ID, "/")
+	if !found {
+		project, id = ip.session.Project, req.ID
+	}
+	if project == "" || id == "" {
+		resp.Diagnostics.AddError("Invalid import ID", fmt.Sprintf("expected project/id or id, got %q", req.ID))
 		return
 	}
-	// find uuid and set uuidString
-	list := clusterList.Msg.Ips
-	uuidStr, err := findUuidByName(list, req.ID)
+	project, err := ip.session.ProjectId(ctx, project)
 	if err != nil {
-		resp.Diagnostics.AddError(fmt.Sprintf("Failed to find IP with address or name %v", req.ID), err.Error())
+		resp.Diagnostics.AddError("Failed to resolve project", err.Error())
 		return
 	}
-	req.ID = uuidStr
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+
+	if _, err := uuid.ParseUUID(id); err != nil {
+		list, err := listIps(ctx, ip.session, project)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to get all public ips", err.Error())
+			return
+		}
+		id, err = findUuidByName(list, id)
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Failed to find IP with address or name %v in project %v", req.ID, project), err.Error())
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project"), project)...)
 }
 
 func findUuidByName(list []*apiv1.IP, nameOrIP string) (string, error) {
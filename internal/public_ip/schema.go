@@ -2,12 +2,15 @@ package ipaddress
 
 import (
 	"context"
+	"regexp"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
-	"github.com/hashicorp/terraform-plugin-framework/attr"
 	dataschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
@@ -48,10 +51,19 @@ func publicIpDataSourceAttributes() map[string]dataschema.Attribute {
 	Another use case would be if you want to have a stable egress address on the internet gateway for your cluster.
 			`,
 		},
-		"tags": dataschema.ListAttribute{
+		"address_family": dataschema.StringAttribute{
+			Computed:    true,
+			Description: "The address family of the address, either 'ipv4' or 'ipv6'.",
+		},
+		"tags": dataschema.SetAttribute{
+			Computed:    true,
+			ElementType: types.StringType,
+			Description: "All tags of this address, including the key=value tags of labels and tags set by others.",
+		},
+		"labels": dataschema.MapAttribute{
 			Computed:    true,
 			ElementType: types.StringType,
-			Description: "The tags used to organize this address.",
+			Description: "All key=value tags of this address.",
 		},
 		"created_at": dataschema.StringAttribute{
 			Computed:    true,
@@ -87,38 +99,80 @@ func publicIpResourceAttributes() map[string]resourceschema.Attribute {
 			Default:     stringdefault.StaticString(""),
 			Description: "Here you can give your IP an optional description for your own use.",
 		},
+		// Neither the assets nor any other API response list the networks and their address families, so both can only be
+		// checked against the enum of the API while planning. Validating the pair needs the networks to be offered by the API first.
 		"network": resourceschema.StringAttribute{
+			Optional:    true,
 			Computed:    true,
-			Description: "The network this address is bound to.",
+			Description: "The network to allocate this address in. Defaults to the network chosen by the API, usually the internet. Changing it allocates a new address. The API offers no list of networks, so an unknown network only fails when the address is allocated, not while planning.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"address_family": resourceschema.StringAttribute{
+			Optional:    true,
+			Computed:    true,
+			Description: "The address family to allocate, either 'ipv4' or 'ipv6'. Defaults to the address family chosen by the API. Changing it allocates a new address. Whether the network offers this address family is only checked when the address is allocated, not while planning.",
+			Validators: []validator.String{
+				stringvalidator.OneOf(addressFamilyV4, addressFamilyV6),
+			},
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+				stringplanmodifier.RequiresReplace(),
+			},
 		},
 		"project": resourceschema.StringAttribute{
 			Computed:    true,
-			Description: "The project this address is part of. Cannot be moved.",
+			Optional:    true,
+			Description: "The project this address is part of. Defaults to the provider project. Cannot be moved.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+				stringplanmodifier.RequiresReplace(),
+			},
 		},
 		"type": resourceschema.StringAttribute{
 			Optional: true,
 			Computed: true,
-			Default:  stringdefault.StaticString("ephemeral"),
+			Default:  stringdefault.StaticString(ipTypeEphemeral),
 			Description: `Determines the type of the public ip address. 
 	If you want the IP to outlive the cluster lifecycle, mark it as static. Otherwise it will be deleted along with the cluster. 
 	Another use case would be if you want to have a stable egress address on the internet gateway for your cluster.
-	Must be either 'static' or 'ephemeral'. Static IPs cannot be made ephemeral.
+	Must be either 'static' or 'ephemeral'. Making an ephemeral IP static keeps the address and warns during plan, 
+	making a static IP ephemeral replaces it with a new address.
 			`,
 			Validators: []validator.String{
-				stringvalidator.OneOf("ephemeral", "static"),
+				stringvalidator.OneOf(ipTypeEphemeral, ipTypeStatic),
 			},
 			PlanModifiers: []planmodifier.String{
-				stringplanmodifier.RequiresReplaceIf(func(ctx context.Context, sr planmodifier.StringRequest, rrifr *stringplanmodifier.RequiresReplaceIfFuncResponse) {
-					rrifr.RequiresReplace = sr.StateValue.ValueString() == "static" &&
-						sr.PlanValue.ValueString() == "ephemeral"
-				}, "desc", "mddesc"),
+				requiresReplaceIfMadeEphemeral(),
 			},
 		},
-		"tags": resourceschema.ListAttribute{
+		"tags": resourceschema.SetAttribute{
+			Optional:    true,
+			ElementType: types.StringType,
+			Description: "The tags used to organize this address, key=value tags are better given as labels. " +
+				"Only these tags are managed, tags set by others like the cloud controller manager are kept. Not managed if unset.",
+		},
+		"labels": resourceschema.MapAttribute{
+			Optional:    true,
+			ElementType: types.StringType,
+			Description: "Labels are stored as key=value tags of this address. Only these keys and those of the provider default_labels are managed.",
+			Validators: []validator.Map{
+				mapvalidator.KeysAre(stringvalidator.RegexMatches(regexp.MustCompile(`^[^=]+$`), "must not contain '='")),
+			},
+		},
+		"effective_labels": resourceschema.MapAttribute{
 			Computed:    true,
 			ElementType: types.StringType,
-			Default:     listdefault.StaticValue(types.ListValueMust(types.StringType, []attr.Value{})),
-			Description: "The tags used to organize this address.",
+			Description: "The labels of this address merged with the provider default_labels.",
+		},
+		"force_destroy": resourceschema.BoolAttribute{
+			Optional: true,
+			Computed: true,
+			Default:  booldefault.StaticBool(false),
+			Description: "Delete the address even if it is still used by a cluster, e.g. by a LoadBalancer service. " +
+				"By default destroying an address in use fails and names its consumers.",
 		},
 		"created_at": resourceschema.StringAttribute{
 			Computed:    true,
@@ -130,3 +184,141 @@ func publicIpResourceAttributes() map[string]resourceschema.Attribute {
 		},
 	}
 }
+
+func publicIpPoolResourceAttributes() map[string]resourceschema.Attribute {
+	return map[string]resourceschema.Attribute{
+		"id": resourceschema.StringAttribute{
+			Computed:    true,
+			Description: "The ID of this pool, all its addresses are tagged with it.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"project": resourceschema.StringAttribute{
+			Computed:    true,
+			Optional:    true,
+			Description: "The project the addresses are part of. Defaults to the provider project. Cannot be moved.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"name_prefix": resourceschema.StringAttribute{
+			Required:    true,
+			Description: "The addresses are named by this prefix and their position in the pool, e.g. 'edge-1'. Changing it renames all addresses.",
+			Validators: []validator.String{
+				stringvalidator.LengthBetween(1, 28),
+			},
+		},
+		"size": resourceschema.Int64Attribute{
+			Required:    true,
+			Description: "The number of addresses in the pool. Growing the pool allocates new addresses, shrinking it releases the newest addresses first.",
+			Validators: []validator.Int64{
+				int64validator.Between(0, maxPoolSize),
+			},
+		},
+		"type": resourceschema.StringAttribute{
+			Optional: true,
+			Computed: true,
+			Default:  stringdefault.StaticString(ipTypeStatic),
+			Description: "The type of all addresses, either 'static' or 'ephemeral'. Defaults to 'static'. " +
+				"Making a static pool ephemeral replaces all its addresses.",
+			Validators: []validator.String{
+				stringvalidator.OneOf(ipTypeEphemeral, ipTypeStatic),
+			},
+			PlanModifiers: []planmodifier.String{
+				requiresReplaceIfMadeEphemeral(),
+			},
+		},
+		"tags": resourceschema.SetAttribute{
+			Optional:    true,
+			ElementType: types.StringType,
+			Description: "Plain tags set on all addresses. Only these tags are managed, tags set by others like the cloud controller manager are kept.",
+			Validators: []validator.Set{
+				setvalidator.ValueStringsAre(stringvalidator.RegexMatches(regexp.MustCompile(`^[^=]+$`), "must not contain '='")),
+			},
+		},
+		"force_destroy": resourceschema.BoolAttribute{
+			Optional: true,
+			Computed: true,
+			Default:  booldefault.StaticBool(false),
+			Description: "Release addresses even if they are still used by a cluster, e.g. by a LoadBalancer service. " +
+				"By default shrinking or destroying the pool fails if an address to release is in use.",
+		},
+		"addresses": resourceschema.ListNestedAttribute{
+			Computed:    true,
+			Description: "The addresses of the pool, oldest first. The order is stable, new addresses are appended.",
+			NestedObject: resourceschema.NestedAttributeObject{
+				Attributes: map[string]resourceschema.Attribute{
+					"id": resourceschema.StringAttribute{
+						Computed:    true,
+						Description: "The ID of the address.",
+					},
+					"ip": resourceschema.StringAttribute{
+						Computed:    true,
+						Description: "The publicly accessible IP address.",
+					},
+					"name": resourceschema.StringAttribute{
+						Computed:    true,
+						Description: "The name of the address.",
+					},
+				},
+			},
+		},
+		"ips": resourceschema.ListAttribute{
+			Computed:    true,
+			ElementType: types.StringType,
+			Description: "The IP addresses of the pool in the order of addresses.",
+		},
+	}
+}
+
+// requiresReplaceIfMadeEphemeral replaces static addresses which should become ephemeral, the api cannot change this.
+func requiresReplaceIfMadeEphemeral() planmodifier.String {
+	return stringplanmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+			resp.RequiresReplace = req.StateValue.ValueString() == ipTypeStatic && req.PlanValue.ValueString() == ipTypeEphemeral
+		},
+		"Static IP addresses cannot be made ephemeral, a new ephemeral address is allocated instead.",
+		"Static IP addresses cannot be made ephemeral, a new ephemeral address is allocated instead.",
+	)
+}
+
+// publicIpResourceAttributesV0 is the schema of version 0 of the public IP resource, only used to upgrade its state.
+func publicIpResourceAttributesV0() map[string]resourceschema.Attribute {
+	return map[string]resourceschema.Attribute{
+		"id": resourceschema.StringAttribute{
+			Computed: true,
+		},
+		"ip": resourceschema.StringAttribute{
+			Computed: true,
+		},
+		"name": resourceschema.StringAttribute{
+			Required: true,
+		},
+		"description": resourceschema.StringAttribute{
+			Optional: true,
+			Computed: true,
+		},
+		"network": resourceschema.StringAttribute{
+			Computed: true,
+		},
+		"project": resourceschema.StringAttribute{
+			Computed: true,
+		},
+		"type": resourceschema.StringAttribute{
+			Optional: true,
+			Computed: true,
+		},
+		"tags": resourceschema.ListAttribute{
+			Computed:    true,
+			ElementType: types.StringType,
+		},
+		"created_at": resourceschema.StringAttribute{
+			Computed: true,
+		},
+		"updated_at": resourceschema.StringAttribute{
+			Computed: true,
+		},
+	}
+}
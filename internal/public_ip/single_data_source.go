@@ -0,0 +1,110 @@
+package ipaddress
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/metal-stack-cloud/terraform-provider-metal/internal/session"
+)
+
+var (
+	_ datasource.DataSource              = &PublicIpSingleDataSource{}
+	_ datasource.DataSourceWithConfigure = &PublicIpSingleDataSource{}
+)
+
+func NewPublicIpSingleDataSource() datasource.DataSource {
+	return &PublicIpSingleDataSource{}
+}
+
+// PublicIpSingleDataSource looks up a single public IP address.
+type PublicIpSingleDataSource struct {
+	session *session.Session
+}
+
+// Metadata implements datasource.DataSource.
+func (*PublicIpSingleDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_public_ip"
+}
+
+// Schema implements datasource.DataSource.
+func (*PublicIpSingleDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := publicIpDataSourceAttributes()
+	attributes["id"] = schema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "The ID that represents this public IP address.",
+		Validators: []validator.String{
+			stringvalidator.ExactlyOneOf(path.MatchRoot("id"), path.MatchRoot("name"), path.MatchRoot("ip")),
+		},
+	}
+	attributes["name"] = schema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "The name of the IP address. Fails if several addresses have this name.",
+	}
+	attributes["ip"] = schema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "The publicly accessible IP address.",
+	}
+	attributes["project"] = schema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "The project this address is part of. Defaults to the provider project.",
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up a single public IP address by `id`, `name` or `ip`. \n" +
+			"Required permissions: `IP List`.",
+		Attributes: attributes,
+	}
+}
+
+// Configure implements datasource.DataSourceWithConfigure.
+func (ip *PublicIpSingleDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	session, ok := req.ProviderData.(*session.Session)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *session.Session, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	ip.session = session
+}
+
+// Read implements datasource.DataSource.
+func (ip *PublicIpSingleDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data publicIpModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	project := data.Project.ValueString()
+	if project == "" {
+		project = ip.session.Project
+	}
+	ips, err := listIps(ctx, ip.session, project)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read public IP Addresses", err.Error())
+		return
+	}
+
+	found, err := findIp(ips, data.Uuid.ValueString(), data.Name.ValueString(), data.Ip.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to find public IP address", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, publicIpFromApi(found))...)
+}
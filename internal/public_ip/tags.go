@@ -0,0 +1,117 @@
+package ipaddress
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// metal-stack tags are either plain strings or key=value pairs, which are exposed as labels.
+
+const (
+	// tagClusterServiceFQN is set by the cloud controller manager on addresses of LoadBalancer services, its value is <cluster>/<namespace>/<service>.
+	tagClusterServiceFQN = "cluster.metal-stack.io/id/namespace/service"
+	// tagClusterID is set on addresses used by a cluster, e.g. as egress address of its gateway.
+	tagClusterID = "cluster.metal-stack.io/id"
+	// tagPoolID marks the addresses allocated by a metal_public_ip_pool, its value is the id of the pool.
+	tagPoolID = "terraform.metal-stack.cloud/public-ip-pool"
+)
+
+func labelTag(key, value string) string {
+	return key + "=" + value
+}
+
+// labelsFromTags parses all key=value tags, plain tags are skipped.
+func labelsFromTags(tags []string) map[string]string {
+	labels := map[string]string{}
+	for _, tag := range tags {
+		if key, value, found := strings.Cut(tag, "="); found {
+			labels[key] = value
+		}
+	}
+	return labels
+}
+
+// mergeTags replaces the tags managed by terraform and keeps all others, like the service tags of the cloud controller manager.
+// Plain tags in oldTags and every tag with a key of oldLabels are removed before newTags and newLabels are added.
+func mergeTags(current, oldTags []string, oldLabels map[string]string, newTags []string, newLabels map[string]string) []string {
+	merged := map[string]bool{}
+	for _, tag := range current {
+		if slices.Contains(oldTags, tag) {
+			continue
+		}
+		if key, _, found := strings.Cut(tag, "="); found {
+			if _, managed := oldLabels[key]; managed {
+				continue
+			}
+		}
+		merged[tag] = true
+	}
+	for _, tag := range newTags {
+		merged[tag] = true
+	}
+	for key, value := range newLabels {
+		merged[labelTag(key, value)] = true
+	}
+	return slices.Sorted(maps.Keys(merged))
+}
+
+// ipConsumers names the clusters and services using an address according to its tags, sorted.
+// An address can be shared by several services, which all set the same tag key, so every tag is looked at.
+func ipConsumers(tags []string) []string {
+	var consumers []string
+	for _, tag := range tags {
+		key, value, found := strings.Cut(tag, "=")
+		if !found {
+			continue
+		}
+		switch key {
+		case tagClusterServiceFQN:
+			cluster, service, found := strings.Cut(value, "/")
+			if !found {
+				consumers = append(consumers, fmt.Sprintf("service %q", value))
+				continue
+			}
+			consumers = append(consumers, fmt.Sprintf("service %q of cluster %q", service, cluster))
+		case tagClusterID:
+			consumers = append(consumers, fmt.Sprintf("cluster %q", value))
+		}
+	}
+	slices.Sort(consumers)
+	return consumers
+}
+
+// mergeLabels returns the provider default labels overridden by the labels of the resource.
+func mergeLabels(defaults map[string]string, labels map[string]string) map[string]string {
+	merged := maps.Clone(defaults)
+	if merged == nil {
+		merged = map[string]string{}
+	}
+	maps.Copy(merged, labels)
+	return merged
+}
+
+func stringsFromValues(values []types.String) []string {
+	if values == nil {
+		return nil
+	}
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		result = append(result, v.ValueString())
+	}
+	return result
+}
+
+func stringMapFromValues(values map[string]types.String) map[string]string {
+	if values == nil {
+		return nil
+	}
+	result := make(map[string]string, len(values))
+	for k, v := range values {
+		result[k] = v.ValueString()
+	}
+	return result
+}
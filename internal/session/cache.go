@@ -0,0 +1,129 @@
+package session
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+)
+
+// DefaultListCacheTTL keeps list responses for roughly a single plan or apply.
+const DefaultListCacheTTL = time.Minute
+
+// ListCache memoizes List responses per service and project.
+// Resources and data sources use it to answer lookups from one list snapshot instead of calling the API for each of them.
+type ListCache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[cacheKey]*cacheEntry
+	// generations count the invalidations per service, snapshots listed across an invalidation are never stored.
+	generations map[string]uint64
+}
+
+type cacheKey struct {
+	service string
+	project string
+}
+
+type cacheEntry struct {
+	mu         sync.Mutex
+	items      any
+	fetchedAt  time.Time
+	generation uint64
+}
+
+// NewListCache creates a cache whose entries expire after ttl.
+func NewListCache(ttl time.Duration) *ListCache {
+	return &ListCache{
+		ttl:         ttl,
+		entries:     map[cacheKey]*cacheEntry{},
+		generations: map[string]uint64{},
+	}
+}
+
+// CachedList returns the items of the given service and project from the cache, calling list only if there is no fresh snapshot.
+// Concurrent callers share a single list call. If the cache is nil, list is always called.
+func CachedList[T any](ctx context.Context, c *ListCache, service, project string, list func(context.Context) ([]T, error)) ([]T, error) {
+	if c == nil {
+		return list(ctx)
+	}
+
+	entry := c.entry(cacheKey{service: service, project: project})
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+
+	generation := c.generation(service)
+	if items, ok := entry.items.([]T); ok && entry.generation == generation && time.Since(entry.fetchedAt) < c.ttl {
+		return items, nil
+	}
+
+	items, err := list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// a mutation running concurrently may not be part of this snapshot, it is returned but not stored
+	if c.generation(service) == generation {
+		entry.items = items
+		entry.fetchedAt = time.Now()
+		entry.generation = generation
+	}
+	return items, nil
+}
+
+// Invalidate drops all snapshots of the given service, regardless of the project.
+func (c *ListCache) Invalidate(service string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generations[service]++
+	for key := range c.entries {
+		if key.service == service {
+			delete(c.entries, key)
+		}
+	}
+}
+
+func (c *ListCache) generation(service string) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.generations[service]
+}
+
+func (c *ListCache) entry(key cacheKey) *cacheEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		entry = &cacheEntry{}
+		c.entries[key] = entry
+	}
+	return entry
+}
+
+// Interceptor returns a connect interceptor invalidating the snapshots of a service whenever one of its mutating methods is called.
+// Snapshots are invalidated before and after the call, so lists running concurrently to the mutation are not stored.
+func (c *ListCache) Interceptor() connect.Interceptor {
+	return connect.UnaryInterceptorFunc(func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			service, method := splitProcedure(req.Spec().Procedure)
+			if isReadMethod(method) {
+				return next(ctx, req)
+			}
+
+			c.Invalidate(service)
+			defer c.Invalidate(service)
+			return next(ctx, req)
+		}
+	})
+}
+
+// splitProcedure splits a procedure like /api.v1.IPService/Allocate into its service and method name.
+func splitProcedure(procedure string) (service, method string) {
+	service, method, _ = strings.Cut(strings.TrimPrefix(procedure, "/"), "/")
+	return service, method
+}
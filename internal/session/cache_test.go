@@ -0,0 +1,110 @@
+package session
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCachedList(t *testing.T) {
+	cache := NewListCache(time.Minute)
+
+	var calls atomic.Int64
+	list := func(ctx context.Context) ([]string, error) {
+		calls.Add(1)
+		return []string{"a", "b"}, nil
+	}
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Go(func() {
+			items, err := CachedList(context.Background(), cache, "api.v1.IPService", "project-a", list)
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"a", "b"}, items)
+		})
+	}
+	wg.Wait()
+	assert.Equal(t, int64(1), calls.Load(), "concurrent readers must share one list call")
+
+	_, err := CachedList(context.Background(), cache, "api.v1.IPService", "project-b", list)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), calls.Load(), "projects must not share snapshots")
+
+	cache.Invalidate("api.v1.ClusterService")
+	_, err = CachedList(context.Background(), cache, "api.v1.IPService", "project-a", list)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), calls.Load(), "other services must not invalidate")
+
+	cache.Invalidate("api.v1.IPService")
+	_, err = CachedList(context.Background(), cache, "api.v1.IPService", "project-a", list)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), calls.Load())
+}
+
+func TestCachedListExpires(t *testing.T) {
+	cache := NewListCache(0)
+
+	var calls int
+	list := func(ctx context.Context) ([]string, error) {
+		calls++
+		return nil, nil
+	}
+
+	for range 3 {
+		_, err := CachedList(context.Background(), cache, "api.v1.IPService", "project-a", list)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 3, calls)
+}
+
+func TestCachedListDisabled(t *testing.T) {
+	var calls int
+	list := func(ctx context.Context) ([]string, error) {
+		calls++
+		return nil, nil
+	}
+
+	for range 3 {
+		_, err := CachedList(context.Background(), nil, "api.v1.IPService", "project-a", list)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 3, calls)
+}
+
+func Test_splitProcedure(t *testing.T) {
+	service, method := splitProcedure("/api.v1.IPService/Allocate")
+	assert.Equal(t, "api.v1.IPService", service)
+	assert.Equal(t, "Allocate", method)
+	assert.False(t, isReadMethod(method))
+
+	_, method = splitProcedure("/api.v1.ClusterService/WatchStatus")
+	assert.True(t, isReadMethod(method))
+}
+
+func TestCachedListConcurrentInvalidation(t *testing.T) {
+	cache := NewListCache(time.Minute)
+
+	var calls int
+	list := func(ctx context.Context) ([]string, error) {
+		calls++
+		if calls == 1 {
+			// a mutation finishes while the first list call is in flight
+			cache.Invalidate("api.v1.IPService")
+			return []string{"stale"}, nil
+		}
+		return []string{"fresh"}, nil
+	}
+
+	items, err := CachedList(context.Background(), cache, "api.v1.IPService", "project-a", list)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"stale"}, items)
+
+	items, err = CachedList(context.Background(), cache, "api.v1.IPService", "project-a", list)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"fresh"}, items, "a snapshot listed across an invalidation must not be stored")
+	assert.Equal(t, 2, calls)
+}
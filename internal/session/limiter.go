@@ -0,0 +1,166 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+)
+
+const (
+	// maxRetries is the number of times a unary call is retried after the API rejected it with ResourceExhausted.
+	maxRetries = 3
+	// defaultRetryBackoff is doubled on every retry of a rejected call which carries no Retry-After hint.
+	defaultRetryBackoff = time.Second
+)
+
+// Limiter caps the concurrency and the request rate of a single provider instance.
+// It is shared by all resources and data sources through the session and wraps every RPC as a connect interceptor.
+type Limiter struct {
+	slots        chan struct{}
+	interval     time.Duration
+	retryBackoff time.Duration
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+// NewLimiter creates a limiter allowing at most maxConcurrent requests in flight and requestsPerSecond requests per second.
+// A value of zero or below disables the respective limit.
+func NewLimiter(maxConcurrent int64, requestsPerSecond float64) *Limiter {
+	l := &Limiter{retryBackoff: defaultRetryBackoff}
+	if maxConcurrent > 0 {
+		l.slots = make(chan struct{}, maxConcurrent)
+	}
+	if requestsPerSecond > 0 {
+		l.interval = time.Duration(float64(time.Second) / requestsPerSecond)
+	}
+	return l
+}
+
+// Acquire blocks until a request may be sent. Every successful call must be followed by Release.
+func (l *Limiter) Acquire(ctx context.Context) error {
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	wait := l.reserve()
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.Release()
+		return ctx.Err()
+	}
+}
+
+// Release frees the concurrency slot taken by Acquire.
+func (l *Limiter) Release() {
+	if l.slots != nil {
+		<-l.slots
+	}
+}
+
+// Backoff delays all requests of this limiter by at least d, e.g. as requested by a Retry-After hint of the API.
+func (l *Limiter) Backoff(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	until := time.Now().Add(d)
+	if until.After(l.next) {
+		l.next = until
+	}
+}
+
+// reserve takes the next free slot of the rate limit and returns how long the caller has to wait for it.
+func (l *Limiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	at := l.next
+	if at.Before(now) {
+		at = now
+	}
+	l.next = at.Add(l.interval)
+	return at.Sub(now)
+}
+
+// Interceptor returns a connect interceptor applying this limiter to unary calls and to opening streams.
+func (l *Limiter) Interceptor() connect.Interceptor {
+	return &limitInterceptor{limiter: l}
+}
+
+type limitInterceptor struct {
+	limiter *Limiter
+}
+
+// WrapUnary implements connect.Interceptor.
+// Calls rejected with ResourceExhausted are retried after the Retry-After hint of the API, or with an exponential backoff without hint.
+func (i *limitInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		for attempt := 0; ; attempt++ {
+			if err := i.limiter.Acquire(ctx); err != nil {
+				return nil, err
+			}
+			resp, err := next(ctx, req)
+			i.limiter.Release()
+
+			var connectErr *connect.Error
+			if err == nil || attempt >= maxRetries || !errors.As(err, &connectErr) || connectErr.Code() != connect.CodeResourceExhausted {
+				return resp, err
+			}
+			backoff, ok := retryAfter(connectErr.Meta())
+			if !ok {
+				backoff = i.limiter.retryBackoff << attempt
+			}
+			i.limiter.Backoff(backoff)
+		}
+	}
+}
+
+// WrapStreamingClient implements connect.Interceptor.
+// Streams only take a concurrency slot while being opened, long running watches would block all other requests otherwise.
+func (i *limitInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return func(ctx context.Context, spec connect.Spec) connect.StreamingClientConn {
+		if err := i.limiter.Acquire(ctx); err != nil {
+			// the stream reports the canceled context on first use
+			return next(ctx, spec)
+		}
+		defer i.limiter.Release()
+		return next(ctx, spec)
+	}
+}
+
+// WrapStreamingHandler implements connect.Interceptor.
+func (i *limitInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return next
+}
+
+// retryAfter parses a Retry-After header, given either in seconds or as HTTP date.
+func retryAfter(header http.Header) (time.Duration, bool) {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0), true
+	}
+	return 0, false
+}
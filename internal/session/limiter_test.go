@@ -0,0 +1,166 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiterConcurrency(t *testing.T) {
+	limiter := NewLimiter(2, 0)
+
+	var (
+		wg       sync.WaitGroup
+		inFlight atomic.Int64
+		peak     atomic.Int64
+	)
+	for range 10 {
+		wg.Go(func() {
+			if err := limiter.Acquire(context.Background()); err != nil {
+				t.Error(err)
+				return
+			}
+			defer limiter.Release()
+
+			current := inFlight.Add(1)
+			for {
+				p := peak.Load()
+				if current <= p || peak.CompareAndSwap(p, current) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			inFlight.Add(-1)
+		})
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, peak.Load(), int64(2))
+}
+
+func TestLimiterRate(t *testing.T) {
+	limiter := NewLimiter(0, 100)
+
+	start := time.Now()
+	for range 5 {
+		assert.NoError(t, limiter.Acquire(context.Background()))
+		limiter.Release()
+	}
+
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+}
+
+func TestLimiterCanceled(t *testing.T) {
+	limiter := NewLimiter(1, 0)
+	assert.NoError(t, limiter.Acquire(context.Background()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, limiter.Acquire(ctx), context.Canceled)
+}
+
+func Test_retryAfter(t *testing.T) {
+	tests := []struct {
+		name   string
+		header http.Header
+		want   time.Duration
+		wantOk bool
+	}{
+		{
+			name:   "no hint",
+			header: http.Header{},
+		},
+		{
+			name:   "seconds",
+			header: http.Header{"Retry-After": []string{"3"}},
+			want:   3 * time.Second,
+			wantOk: true,
+		},
+		{
+			name:   "date in the past",
+			header: http.Header{"Retry-After": []string{"Wed, 21 Oct 2015 07:28:00 GMT"}},
+			want:   0,
+			wantOk: true,
+		},
+		{
+			name:   "garbage",
+			header: http.Header{"Retry-After": []string{"soon"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := retryAfter(tt.header)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLimiterRetries(t *testing.T) {
+	rateLimited := func() error {
+		err := connect.NewError(connect.CodeResourceExhausted, errors.New("rate limited"))
+		err.Meta().Set("Retry-After", "0")
+		return err
+	}
+	tests := []struct {
+		name      string
+		err       func() error
+		wantCalls int
+	}{
+		{
+			name:      "success",
+			err:       func() error { return nil },
+			wantCalls: 1,
+		},
+		{
+			name:      "rate limited with retry after hint",
+			err:       rateLimited,
+			wantCalls: maxRetries + 1,
+		},
+		{
+			name:      "rate limited without hint",
+			err:       func() error { return connect.NewError(connect.CodeResourceExhausted, errors.New("rate limited")) },
+			wantCalls: maxRetries + 1,
+		},
+		{
+			name:      "other error",
+			err:       func() error { return connect.NewError(connect.CodeUnavailable, errors.New("unavailable")) },
+			wantCalls: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			limiter := NewLimiter(1, 0)
+			limiter.retryBackoff = time.Millisecond
+			unary := limiter.Interceptor().WrapUnary(func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+				calls++
+				return nil, tt.err()
+			})
+			_, _ = unary(context.Background(), connect.NewRequest(&struct{}{}))
+			assert.Equal(t, tt.wantCalls, calls)
+		})
+	}
+}
+
+func TestLimiterStreams(t *testing.T) {
+	limiter := NewLimiter(1, 0)
+	opened := false
+	streaming := limiter.Interceptor().WrapStreamingClient(func(ctx context.Context, spec connect.Spec) connect.StreamingClientConn {
+		opened = true
+		// the slot is taken while the stream is opened
+		assert.Len(t, limiter.slots, 1)
+		return nil
+	})
+	streaming(context.Background(), connect.Spec{Procedure: "/api.v1.ClusterService/WatchStatus"})
+	assert.True(t, opened)
+	// and released afterwards, so a long running watch does not block other requests
+	assert.Empty(t, limiter.slots)
+}
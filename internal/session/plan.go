@@ -0,0 +1,147 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// AttributeGetter is implemented by both, plans and states.
+type AttributeGetter interface {
+	GetAttribute(ctx context.Context, p path.Path, target any) diag.Diagnostics
+}
+
+// ProjectsFunc returns the projects a resource belongs to, read from its plan or state. Unknown projects are left out.
+type ProjectsFunc func(ctx context.Context, data AttributeGetter) ([]string, diag.Diagnostics)
+
+// ProjectAttribute reads the project from a string attribute, like "project" or the "id" of a project.
+func ProjectAttribute(p path.Path) ProjectsFunc {
+	return func(ctx context.Context, data AttributeGetter) ([]string, diag.Diagnostics) {
+		var project types.String
+		diags := data.GetAttribute(ctx, p, &project)
+		if diags.HasError() || project.IsUnknown() || project.ValueString() == "" {
+			return nil, diags
+		}
+		return []string{project.ValueString()}, diags
+	}
+}
+
+// ProjectKeys reads the projects from the keys of a map attribute, like the project roles of a token.
+func ProjectKeys(p path.Path) ProjectsFunc {
+	return func(ctx context.Context, data AttributeGetter) ([]string, diag.Diagnostics) {
+		var projects types.Map
+		diags := data.GetAttribute(ctx, p, &projects)
+		if diags.HasError() || projects.IsUnknown() {
+			return nil, diags
+		}
+		var result []string
+		for project := range projects.Elements() {
+			result = append(result, project)
+		}
+		slices.Sort(result)
+		return result, diags
+	}
+}
+
+// CheckPlan adds plan errors for changes the provider configuration forbids, i.e. any change in read only mode
+// and changes to resources outside of the allowed projects. Resources call it last in ModifyPlan, so that their own plan
+// modifications are checked as well.
+// The projects of the resource are read from its "project" attribute, unless other projects functions are given.
+// They are taken from the state as well as from the plan, so that a resource can neither be changed in nor moved out of a
+// project which is not allowed.
+func (s *Session) CheckPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, projects ...ProjectsFunc) {
+	action := s.checkAction(req, resp)
+	if action == "" || len(s.AllowedProjects) == 0 {
+		return
+	}
+	if len(projects) == 0 {
+		projects = []ProjectsFunc{ProjectAttribute(path.Root("project"))}
+	}
+
+	var affected []string
+	for _, projectsOf := range projects {
+		if !req.State.Raw.IsNull() {
+			fromState, diags := projectsOf(ctx, req.State)
+			resp.Diagnostics.Append(diags...)
+			affected = append(affected, fromState...)
+		}
+		if !req.Plan.Raw.IsNull() {
+			fromPlan, diags := projectsOf(ctx, resp.Plan)
+			resp.Diagnostics.Append(diags...)
+			affected = append(affected, fromPlan...)
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// resources without a project of their own are placed in the provider project, which is checked at configure time
+
+	slices.Sort(affected)
+	for _, project := range slices.Compact(affected) {
+		resolved, err := s.ProjectId(ctx, project)
+		if err != nil {
+			resp.Diagnostics.AddError("Project not allowed", fmt.Sprintf("The project %q cannot be checked against allowed_projects: %s", project, err.Error()))
+			continue
+		}
+		if !slices.Contains(s.AllowedProjects, resolved) {
+			resp.Diagnostics.AddError(
+				"Project not allowed",
+				fmt.Sprintf("The resource would need to %s in project %q, which is not part of allowed_projects.", action, project),
+			)
+		}
+	}
+}
+
+// CheckTenantPlan is CheckPlan for resources of the tenant, which are not part of a single project, like tenant members.
+// Changing them affects every project of the tenant, so they are rejected as soon as allowed_projects is set.
+func (s *Session) CheckTenantPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	action := s.checkAction(req, resp)
+	if action == "" || len(s.AllowedProjects) == 0 {
+		return
+	}
+	resp.Diagnostics.AddError(
+		"Tenant not allowed",
+		fmt.Sprintf("The resource would need to %s in the tenant, which affects all of its projects, but the provider is restricted to allowed_projects.", action),
+	)
+}
+
+// checkAction returns the action of a plan, or an empty string if nothing changes or the change is forbidden by read only mode.
+func (s *Session) checkAction(req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) string {
+	if s == nil {
+		return ""
+	}
+
+	var action string
+	switch {
+	case req.State.Raw.IsNull():
+		action = "create"
+	case req.Plan.Raw.IsNull():
+		action = "destroy"
+	case !resp.Plan.Raw.Equal(req.State.Raw):
+		action = "update"
+	default:
+		return ""
+	}
+
+	if s.ReadOnly {
+		resp.Diagnostics.AddError(
+			"Provider is read only",
+			fmt.Sprintf("The resource would need to %s, but the provider is configured with read_only = true and does not change anything.", action),
+		)
+		return ""
+	}
+	return action
+}
+
+// ProjectId returns the UUID of a project given by UUID or by name.
+func (s *Session) ProjectId(ctx context.Context, nameOrId string) (string, error) {
+	if s.ResolveProject == nil {
+		return nameOrId, nil
+	}
+	return s.ResolveProject(ctx, nameOrId)
+}
@@ -0,0 +1,237 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	allowedProject = "7c8f1d2e-3a4b-4c5d-8e6f-9a0b1c2d3e4f"
+	otherProject   = "0b9e8d7c-6a5b-4c3d-2e1f-0a9b8c7d6e5f"
+)
+
+var testPlanSchema = schema.Schema{
+	Attributes: map[string]schema.Attribute{
+		"id":            schema.StringAttribute{Computed: true},
+		"project":       schema.StringAttribute{Optional: true},
+		"name":          schema.StringAttribute{Optional: true},
+		"project_roles": schema.MapAttribute{Optional: true, ElementType: types.StringType},
+	},
+}
+
+// testPlanValue returns nil as null object, so that tests can express creation and destruction.
+func testPlanValue(values map[string]tftypes.Value) tftypes.Value {
+	objectType := testPlanSchema.Type().TerraformType(context.Background())
+	if values == nil {
+		return tftypes.NewValue(objectType, nil)
+	}
+	all := map[string]tftypes.Value{
+		"id":            tftypes.NewValue(tftypes.String, nil),
+		"project":       tftypes.NewValue(tftypes.String, nil),
+		"name":          tftypes.NewValue(tftypes.String, nil),
+		"project_roles": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+	}
+	for k, v := range values {
+		all[k] = v
+	}
+	return tftypes.NewValue(objectType, all)
+}
+
+func TestCheckPlan(t *testing.T) {
+	s := &Session{
+		AllowedProjects: []string{allowedProject},
+		ResolveProject: func(ctx context.Context, nameOrId string) (string, error) {
+			switch nameOrId {
+			case "allowed":
+				return allowedProject, nil
+			case "other":
+				return otherProject, nil
+			case allowedProject, otherProject:
+				return nameOrId, nil
+			}
+			return "", fmt.Errorf("no project named %q found", nameOrId)
+		},
+	}
+	str := func(v string) tftypes.Value { return tftypes.NewValue(tftypes.String, v) }
+	roles := func(projects ...string) tftypes.Value {
+		values := map[string]tftypes.Value{}
+		for _, p := range projects {
+			values[p] = str("owner")
+		}
+		return tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, values)
+	}
+
+	tests := []struct {
+		name     string
+		session  *Session
+		state    map[string]tftypes.Value
+		plan     map[string]tftypes.Value
+		projects []ProjectsFunc
+		wantErr  string
+	}{
+		{
+			name: "create in allowed project by id",
+			plan: map[string]tftypes.Value{"project": str(allowedProject)},
+		},
+		{
+			name: "create in allowed project by name",
+			plan: map[string]tftypes.Value{"project": str("allowed")},
+		},
+		{
+			name:    "create in other project",
+			plan:    map[string]tftypes.Value{"project": str("other")},
+			wantErr: "Project not allowed",
+		},
+		{
+			name:    "unknown project name",
+			plan:    map[string]tftypes.Value{"project": str("unknown")},
+			wantErr: "Project not allowed",
+		},
+		{
+			name: "no project uses the provider project",
+			plan: map[string]tftypes.Value{"name": str("a")},
+		},
+		{
+			name:  "unchanged resource in other project",
+			state: map[string]tftypes.Value{"project": str(otherProject)},
+			plan:  map[string]tftypes.Value{"project": str(otherProject)},
+		},
+		{
+			name:    "destroy in other project",
+			state:   map[string]tftypes.Value{"project": str(otherProject)},
+			wantErr: "Project not allowed",
+		},
+		{
+			name:    "move out of other project",
+			state:   map[string]tftypes.Value{"project": str(otherProject)},
+			plan:    map[string]tftypes.Value{"project": str(allowedProject)},
+			wantErr: "Project not allowed",
+		},
+		{
+			name:     "update project resource by id",
+			state:    map[string]tftypes.Value{"id": str(otherProject), "name": str("a")},
+			plan:     map[string]tftypes.Value{"id": str(otherProject), "name": str("b")},
+			projects: []ProjectsFunc{ProjectAttribute(path.Root("id"))},
+			wantErr:  "Project not allowed",
+		},
+		{
+			name:     "create project with unknown id",
+			plan:     map[string]tftypes.Value{"id": tftypes.NewValue(tftypes.String, tftypes.UnknownValue), "name": str("a")},
+			projects: []ProjectsFunc{ProjectAttribute(path.Root("id"))},
+		},
+		{
+			name:     "destroy token with roles in other project",
+			state:    map[string]tftypes.Value{"project_roles": roles(allowedProject, otherProject)},
+			projects: []ProjectsFunc{ProjectKeys(path.Root("project_roles"))},
+			wantErr:  "Project not allowed",
+		},
+		{
+			name:     "create token with roles in allowed project",
+			plan:     map[string]tftypes.Value{"project_roles": roles(allowedProject)},
+			projects: []ProjectsFunc{ProjectKeys(path.Root("project_roles"))},
+		},
+		{
+			name:    "read only",
+			session: &Session{ReadOnly: true},
+			plan:    map[string]tftypes.Value{"project": str(allowedProject)},
+			wantErr: "Provider is read only",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := s
+			if tt.session != nil {
+				session = tt.session
+			}
+			req := resource.ModifyPlanRequest{
+				State: tfsdk.State{Schema: testPlanSchema, Raw: testPlanValue(tt.state)},
+				Plan:  tfsdk.Plan{Schema: testPlanSchema, Raw: testPlanValue(tt.plan)},
+			}
+			resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+
+			session.CheckPlan(context.Background(), req, resp, tt.projects...)
+
+			if tt.wantErr == "" {
+				assert.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+				return
+			}
+			if assert.True(t, resp.Diagnostics.HasError()) {
+				assert.Equal(t, tt.wantErr, resp.Diagnostics.Errors()[0].Summary())
+			}
+		})
+	}
+}
+
+func TestCheckTenantPlan(t *testing.T) {
+	str := func(v string) tftypes.Value { return tftypes.NewValue(tftypes.String, v) }
+
+	tests := []struct {
+		name    string
+		session *Session
+		state   map[string]tftypes.Value
+		plan    map[string]tftypes.Value
+		wantErr string
+	}{
+		{
+			name:    "all projects allowed",
+			session: &Session{},
+			plan:    map[string]tftypes.Value{"name": str("a")},
+		},
+		{
+			name:    "create with allowed projects",
+			session: &Session{AllowedProjects: []string{allowedProject}},
+			plan:    map[string]tftypes.Value{"name": str("a")},
+			wantErr: "Tenant not allowed",
+		},
+		{
+			name:    "destroy with allowed projects",
+			session: &Session{AllowedProjects: []string{allowedProject}},
+			state:   map[string]tftypes.Value{"name": str("a")},
+			wantErr: "Tenant not allowed",
+		},
+		{
+			name:    "unchanged with allowed projects",
+			session: &Session{AllowedProjects: []string{allowedProject}},
+			state:   map[string]tftypes.Value{"name": str("a")},
+			plan:    map[string]tftypes.Value{"name": str("a")},
+		},
+		{
+			name:    "read only",
+			session: &Session{ReadOnly: true},
+			plan:    map[string]tftypes.Value{"name": str("a")},
+			wantErr: "Provider is read only",
+		},
+		{
+			name: "not configured",
+			plan: map[string]tftypes.Value{"name": str("a")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := resource.ModifyPlanRequest{
+				State: tfsdk.State{Schema: testPlanSchema, Raw: testPlanValue(tt.state)},
+				Plan:  tfsdk.Plan{Schema: testPlanSchema, Raw: testPlanValue(tt.plan)},
+			}
+			resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+
+			tt.session.CheckTenantPlan(context.Background(), req, resp)
+
+			if tt.wantErr == "" {
+				assert.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+				return
+			}
+			if assert.True(t, resp.Diagnostics.HasError()) {
+				assert.Equal(t, tt.wantErr, resp.Diagnostics.Errors()[0].Summary())
+			}
+		})
+	}
+}
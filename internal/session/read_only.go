@@ -0,0 +1,92 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"connectrpc.com/connect"
+)
+
+// readMethods are read methods of the API not following the Get, List and Watch naming.
+var readMethods = []string{
+	"InviteGet",
+	"InvitesList",
+	"TokenScopedList",
+}
+
+// isReadMethod reports whether a method only reads. GetCredentials is no read, it issues new cluster credentials.
+// Both the read only mode and the cache rely on it, so every unknown method is treated as mutating.
+func isReadMethod(method string) bool {
+	if method == "GetCredentials" {
+		return false
+	}
+	if slices.Contains(readMethods, method) {
+		return true
+	}
+	for _, prefix := range []string{"Get", "List", "Watch"} {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// ReadOnlyInterceptor returns a connect interceptor rejecting every call of a method that is not known to only read before it is sent.
+func ReadOnlyInterceptor() connect.Interceptor {
+	return &readOnlyInterceptor{}
+}
+
+type readOnlyInterceptor struct{}
+
+// WrapUnary implements connect.Interceptor.
+func (*readOnlyInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if err := checkReadOnly(req.Spec().Procedure); err != nil {
+			return nil, err
+		}
+		return next(ctx, req)
+	}
+}
+
+// WrapStreamingClient implements connect.Interceptor.
+// A rejected stream is never opened, it reports the error on first use.
+func (*readOnlyInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return func(ctx context.Context, spec connect.Spec) connect.StreamingClientConn {
+		if err := checkReadOnly(spec.Procedure); err != nil {
+			return &rejectedStream{spec: spec, err: err}
+		}
+		return next(ctx, spec)
+	}
+}
+
+// WrapStreamingHandler implements connect.Interceptor.
+func (*readOnlyInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return next
+}
+
+// checkReadOnly rejects procedures like /api.v1.IPService/Allocate whose method is not a read method.
+func checkReadOnly(procedure string) error {
+	if _, method := splitProcedure(procedure); !isReadMethod(method) {
+		return connect.NewError(connect.CodePermissionDenied, fmt.Errorf("the provider is configured read only, refusing to call %s", procedure))
+	}
+	return nil
+}
+
+// rejectedStream is a connect.StreamingClientConn failing every send and receive with err.
+type rejectedStream struct {
+	spec connect.Spec
+	err  error
+}
+
+func (s *rejectedStream) Spec() connect.Spec           { return s.spec }
+func (s *rejectedStream) Peer() connect.Peer           { return connect.Peer{} }
+func (s *rejectedStream) Send(any) error               { return s.err }
+func (s *rejectedStream) RequestHeader() http.Header   { return http.Header{} }
+func (s *rejectedStream) CloseRequest() error          { return nil }
+func (s *rejectedStream) Receive(any) error            { return s.err }
+func (s *rejectedStream) ResponseHeader() http.Header  { return http.Header{} }
+func (s *rejectedStream) ResponseTrailer() http.Header { return http.Header{} }
+func (s *rejectedStream) CloseResponse() error         { return nil }
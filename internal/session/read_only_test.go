@@ -0,0 +1,124 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_checkReadOnly(t *testing.T) {
+	tests := []struct {
+		procedure string
+		blocked   bool
+	}{
+		{procedure: "/api.v1.AssetService/List"},
+		{procedure: "/api.v1.AuditService/List"},
+		{procedure: "/api.v1.ClusterService/Create", blocked: true},
+		{procedure: "/api.v1.ClusterService/Delete", blocked: true},
+		{procedure: "/api.v1.ClusterService/Get"},
+		{procedure: "/api.v1.ClusterService/GetCredentials", blocked: true},
+		{procedure: "/api.v1.ClusterService/List"},
+		{procedure: "/api.v1.ClusterService/Operate", blocked: true},
+		{procedure: "/api.v1.ClusterService/Update", blocked: true},
+		{procedure: "/api.v1.ClusterService/WatchStatus"},
+		{procedure: "/api.v1.HealthService/Get"},
+		{procedure: "/api.v1.IPService/Allocate", blocked: true},
+		{procedure: "/api.v1.IPService/Delete", blocked: true},
+		{procedure: "/api.v1.IPService/Get"},
+		{procedure: "/api.v1.IPService/List"},
+		{procedure: "/api.v1.IPService/Update", blocked: true},
+		{procedure: "/api.v1.MethodService/TokenScopedList"},
+		{procedure: "/api.v1.PaymentService/GetDefaultPrices"},
+		{procedure: "/api.v1.PaymentService/CreateOrUpdateCustomer", blocked: true},
+		{procedure: "/api.v1.PaymentService/DeletePaymentMethod", blocked: true},
+		{procedure: "/api.v1.ProjectService/Create", blocked: true},
+		{procedure: "/api.v1.ProjectService/Delete", blocked: true},
+		{procedure: "/api.v1.ProjectService/Get"},
+		{procedure: "/api.v1.ProjectService/Invite", blocked: true},
+		{procedure: "/api.v1.ProjectService/InviteAccept", blocked: true},
+		{procedure: "/api.v1.ProjectService/InviteDelete", blocked: true},
+		{procedure: "/api.v1.ProjectService/InviteGet"},
+		{procedure: "/api.v1.ProjectService/InvitesList"},
+		{procedure: "/api.v1.ProjectService/Leave", blocked: true},
+		{procedure: "/api.v1.ProjectService/List"},
+		{procedure: "/api.v1.ProjectService/RemoveMember", blocked: true},
+		{procedure: "/api.v1.ProjectService/Update", blocked: true},
+		{procedure: "/api.v1.ProjectService/UpdateMember", blocked: true},
+		{procedure: "/api.v1.SnapshotService/Get"},
+		{procedure: "/api.v1.SnapshotService/List"},
+		{procedure: "/api.v1.TenantService/Get"},
+		{procedure: "/api.v1.TenantService/RemoveMember", blocked: true},
+		{procedure: "/api.v1.TenantService/UpdateMember", blocked: true},
+		{procedure: "/api.v1.TokenService/Create", blocked: true},
+		{procedure: "/api.v1.TokenService/List"},
+		{procedure: "/api.v1.TokenService/Revoke", blocked: true},
+		{procedure: "/api.v1.UsageService/List"},
+		{procedure: "/api.v1.UserService/Get"},
+		{procedure: "/api.v1.VersionService/Get"},
+		{procedure: "/api.v1.VolumeService/Get"},
+		{procedure: "/api.v1.VolumeService/List"},
+		{procedure: "/api.v1.VolumeService/Unknown", blocked: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.procedure, func(t *testing.T) {
+			err := checkReadOnly(tt.procedure)
+			if !tt.blocked {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+		})
+	}
+}
+
+// jsonCodec lets the test call plain structs instead of protobuf messages.
+type jsonCodec struct{}
+
+func (jsonCodec) Name() string                       { return "proto" }
+func (jsonCodec) Marshal(v any) ([]byte, error)      { return json.Marshal(v) }
+func (jsonCodec) Unmarshal(data []byte, v any) error { return json.Unmarshal(data, v) }
+
+func TestReadOnlyInterceptor(t *testing.T) {
+	type message struct{}
+
+	mux := http.NewServeMux()
+	for _, procedure := range []string{"/api.v1.IPService/List", "/api.v1.IPService/Delete"} {
+		mux.Handle(procedure, connect.NewUnaryHandler(procedure, func(ctx context.Context, req *connect.Request[message]) (*connect.Response[message], error) {
+			return connect.NewResponse(&message{}), nil
+		}, connect.WithCodec(jsonCodec{})))
+	}
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	call := func(procedure string) error {
+		client := connect.NewClient[message, message](server.Client(), server.URL+procedure, connect.WithCodec(jsonCodec{}), connect.WithInterceptors(ReadOnlyInterceptor()))
+		_, err := client.CallUnary(context.Background(), connect.NewRequest(&message{}))
+		return err
+	}
+
+	assert.NoError(t, call("/api.v1.IPService/List"))
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(call("/api.v1.IPService/Delete")))
+}
+
+func TestReadOnlyInterceptorStreams(t *testing.T) {
+	type message struct{}
+
+	opened := false
+	streaming := ReadOnlyInterceptor().WrapStreamingClient(func(ctx context.Context, spec connect.Spec) connect.StreamingClientConn {
+		opened = true
+		return nil
+	})
+
+	conn := streaming(context.Background(), connect.Spec{Procedure: "/api.v1.ClusterService/Operate"})
+	assert.False(t, opened)
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(conn.Send(&message{})))
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(conn.Receive(&message{})))
+
+	streaming(context.Background(), connect.Spec{Procedure: "/api.v1.ClusterService/WatchStatus"})
+	assert.True(t, opened)
+}
@@ -1,10 +1,38 @@
 package session
 
 import (
+	"context"
+
+	"github.com/golang-jwt/jwt/v5"
 	mclient "github.com/metal-stack-cloud/api/go/client"
 )
 
+// MachineTokenType is the type claim of api tokens issued by the token service, in contrast to tokens of a console login.
+const MachineTokenType = "api"
+
 type Session struct {
 	Client  mclient.Client
 	Project string
+	Limiter *Limiter
+	// Cache is nil unless list caching is enabled in the provider configuration.
+	Cache *ListCache
+	// ReadOnly rejects every mutating call and every plan that would change a resource.
+	ReadOnly bool
+	// AllowedProjects contains the UUIDs of all projects resources may be planned in. If empty, all projects are allowed.
+	AllowedProjects []string
+	// ResolveProject returns the UUID of a project given by UUID or by name. If nil, projects are expected to be given by UUID.
+	ResolveProject func(ctx context.Context, nameOrId string) (string, error)
+	// TokenClaims are parsed from the api token without verification, they identify machine tokens the user service does not know.
+	TokenClaims *jwt.RegisteredClaims
+	// TokenType is the type claim of the api token, see MachineTokenType.
+	TokenType string
+	// ConsoleUrl is the base URL of the console belonging to the API, e.g. to build invite links.
+	ConsoleUrl string
+	// DefaultLabels are merged into the labels of every public IP address, labels of the resource take precedence.
+	DefaultLabels map[string]string
+}
+
+// IsMachineToken returns true if the claims of the api token identify it as a machine token.
+func (s *Session) IsMachineToken() bool {
+	return s.TokenType == MachineTokenType
 }
@@ -0,0 +1,19 @@
+package session
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	apiv1 "github.com/metal-stack-cloud/api/go/api/v1"
+)
+
+// ProviderTenant returns the tenant owning the provider project, which is the default for everything scoped to a tenant.
+func (s *Session) ProviderTenant(ctx context.Context) (string, error) {
+	projectResp, err := s.Client.Apiv1().Project().Get(ctx, connect.NewRequest(&apiv1.ProjectServiceGetRequest{
+		Project: s.Project,
+	}))
+	if err != nil {
+		return "", err
+	}
+	return projectResp.Msg.GetProject().GetTenant(), nil
+}
@@ -0,0 +1,82 @@
+package shared
+
+import (
+	"fmt"
+
+	apiv1 "github.com/metal-stack-cloud/api/go/api/v1"
+)
+
+// ProjectRoles are the roles which can be granted on a project.
+var ProjectRoles = []string{"owner", "editor", "viewer"}
+
+// TenantRoles are the roles which can be granted on a tenant. Guests only see the projects they are a member of.
+var TenantRoles = []string{"owner", "editor", "viewer", "guest"}
+
+func ProjectRoleFromString(role string) (apiv1.ProjectRole, error) {
+	switch role {
+	case "owner":
+		return apiv1.ProjectRole_PROJECT_ROLE_OWNER, nil
+	case "editor":
+		return apiv1.ProjectRole_PROJECT_ROLE_EDITOR, nil
+	case "viewer":
+		return apiv1.ProjectRole_PROJECT_ROLE_VIEWER, nil
+	default:
+		return apiv1.ProjectRole_PROJECT_ROLE_UNSPECIFIED, fmt.Errorf("project role %q is invalid, must be one of %v", role, ProjectRoles)
+	}
+}
+
+func ProjectRoleToString(role apiv1.ProjectRole) string {
+	switch role {
+	case apiv1.ProjectRole_PROJECT_ROLE_OWNER:
+		return "owner"
+	case apiv1.ProjectRole_PROJECT_ROLE_EDITOR:
+		return "editor"
+	case apiv1.ProjectRole_PROJECT_ROLE_VIEWER:
+		return "viewer"
+	default:
+		return "unspecified"
+	}
+}
+
+func TenantRoleFromString(role string) (apiv1.TenantRole, error) {
+	switch role {
+	case "owner":
+		return apiv1.TenantRole_TENANT_ROLE_OWNER, nil
+	case "editor":
+		return apiv1.TenantRole_TENANT_ROLE_EDITOR, nil
+	case "viewer":
+		return apiv1.TenantRole_TENANT_ROLE_VIEWER, nil
+	case "guest":
+		return apiv1.TenantRole_TENANT_ROLE_GUEST, nil
+	default:
+		return apiv1.TenantRole_TENANT_ROLE_UNSPECIFIED, fmt.Errorf("tenant role %q is invalid, must be one of %v", role, TenantRoles)
+	}
+}
+
+func TenantRoleToString(role apiv1.TenantRole) string {
+	switch role {
+	case apiv1.TenantRole_TENANT_ROLE_OWNER:
+		return "owner"
+	case apiv1.TenantRole_TENANT_ROLE_EDITOR:
+		return "editor"
+	case apiv1.TenantRole_TENANT_ROLE_VIEWER:
+		return "viewer"
+	case apiv1.TenantRole_TENANT_ROLE_GUEST:
+		return "guest"
+	default:
+		return "unspecified"
+	}
+}
+
+func OAuthProviderToString(provider apiv1.OAuthProvider) string {
+	switch provider {
+	case apiv1.OAuthProvider_O_AUTH_PROVIDER_GITHUB:
+		return "github"
+	case apiv1.OAuthProvider_O_AUTH_PROVIDER_AZURE:
+		return "azure"
+	case apiv1.OAuthProvider_O_AUTH_PROVIDER_GOOGLE:
+		return "google"
+	default:
+		return "unspecified"
+	}
+}
@@ -0,0 +1,45 @@
+package shared
+
+import (
+	"testing"
+
+	apiv1 "github.com/metal-stack-cloud/api/go/api/v1"
+	assert "github.com/stretchr/testify/assert"
+)
+
+func Test_ProjectRole(t *testing.T) {
+	for _, role := range ProjectRoles {
+		apiRole, err := ProjectRoleFromString(role)
+		assert.NoError(t, err)
+		assert.Equal(t, role, ProjectRoleToString(apiRole))
+	}
+
+	_, err := ProjectRoleFromString("admin")
+	assert.EqualError(t, err, `project role "admin" is invalid, must be one of [owner editor viewer]`)
+}
+
+func Test_TenantRole(t *testing.T) {
+	for _, role := range TenantRoles {
+		apiRole, err := TenantRoleFromString(role)
+		assert.NoError(t, err)
+		assert.Equal(t, role, TenantRoleToString(apiRole))
+	}
+
+	_, err := TenantRoleFromString("admin")
+	assert.EqualError(t, err, `tenant role "admin" is invalid, must be one of [owner editor viewer guest]`)
+}
+
+func Test_OAuthProviderToString(t *testing.T) {
+	tests := []struct {
+		provider apiv1.OAuthProvider
+		want     string
+	}{
+		{provider: apiv1.OAuthProvider_O_AUTH_PROVIDER_GITHUB, want: "github"},
+		{provider: apiv1.OAuthProvider_O_AUTH_PROVIDER_AZURE, want: "azure"},
+		{provider: apiv1.OAuthProvider_O_AUTH_PROVIDER_GOOGLE, want: "google"},
+		{provider: apiv1.OAuthProvider_O_AUTH_PROVIDER_UNSPECIFIED, want: "unspecified"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, OAuthProviderToString(tt.provider))
+	}
+}
@@ -9,6 +9,7 @@ import (
 	datasource "github.com/hashicorp/terraform-plugin-framework/datasource"
 	schema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	apiv1 "github.com/metal-stack-cloud/api/go/api/v1"
+	"github.com/metal-stack-cloud/api/go/api/v1/apiv1connect"
 	session "github.com/metal-stack-cloud/terraform-provider-metal/internal/session"
 )
 
@@ -85,17 +86,21 @@ func (s *SnapshotDataSource) Read(ctx context.Context, request datasource.ReadRe
 		}
 		snapshot = clientResponse.Msg.Snapshot
 	} else {
-		listRequestMessage := &apiv1.SnapshotServiceListRequest{
-			Project: project,
-		}
 		// get snapshotList type snapshots []*snapshot
-		snapshotList, err := s.session.Client.Apiv1().Snapshot().List(ctx, connect.NewRequest(listRequestMessage))
+		list, err := session.CachedList(ctx, s.session.Cache, apiv1connect.SnapshotServiceName, project, func(ctx context.Context) ([]*apiv1.Snapshot, error) {
+			snapshotList, err := s.session.Client.Apiv1().Snapshot().List(ctx, connect.NewRequest(&apiv1.SnapshotServiceListRequest{
+				Project: project,
+			}))
+			if err != nil {
+				return nil, err
+			}
+			return snapshotList.Msg.GetSnapshots(), nil
+		})
 		if err != nil {
 			response.Diagnostics.AddError("failed to get snapshot list", err.Error())
 			return
 		}
 		// find uuid and set uuidString
-		list := snapshotList.Msg.GetSnapshots()
 		fmt.Println(list)
 		if data.Name.ValueString() != "" {
 			snapshot = findSnapshotByName(list, data.Name.ValueString())
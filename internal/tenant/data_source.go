@@ -0,0 +1,87 @@
+package tenant
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	apiv1 "github.com/metal-stack-cloud/api/go/api/v1"
+	"github.com/metal-stack-cloud/terraform-provider-metal/internal/session"
+)
+
+var (
+	_ datasource.DataSource              = &TenantDataSource{}
+	_ datasource.DataSourceWithConfigure = &TenantDataSource{}
+)
+
+func NewTenantDataSource() datasource.DataSource {
+	return &TenantDataSource{}
+}
+
+// TenantDataSource defines the data source implementation.
+type TenantDataSource struct {
+	session *session.Session
+}
+
+// Metadata implements datasource.DataSource.
+func (*TenantDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tenant"
+}
+
+// Schema implements datasource.DataSource.
+func (*TenantDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A tenant is the organization owning projects, permissions and billing. \n" +
+			"Without an `id`, the tenant owning the provider project is read. \n" +
+			"Required permissions: `Tenant Get`, `Project Get` without an `id`.",
+		Attributes: tenantDataSourceAttributes(),
+	}
+}
+
+// Configure implements datasource.DataSourceWithConfigure.
+func (t *TenantDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	session, ok := req.ProviderData.(*session.Session)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *session.Session, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	t.session = session
+}
+
+// Read implements datasource.DataSource.
+func (t *TenantDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data tenantModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	login := data.Login.ValueString()
+	if login == "" {
+		tenant, err := t.session.ProviderTenant(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to determine the tenant of the provider project", err.Error())
+			return
+		}
+		login = tenant
+	}
+
+	tenantResp, err := t.session.Client.Apiv1().Tenant().Get(ctx, connect.NewRequest(&apiv1.TenantServiceGetRequest{
+		Login: login,
+	}))
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get tenant", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, tenantFromApi(tenantResp.Msg.Tenant))...)
+}
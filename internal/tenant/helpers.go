@@ -0,0 +1,25 @@
+package tenant
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	apiv1 "github.com/metal-stack-cloud/api/go/api/v1"
+	"github.com/metal-stack-cloud/terraform-provider-metal/internal/session"
+)
+
+// getTenantMember returns the member of the tenant, or nil if the user is no member.
+func getTenantMember(ctx context.Context, s *session.Session, tenant, memberId string) (*apiv1.TenantMember, error) {
+	tenantResp, err := s.Client.Apiv1().Tenant().Get(ctx, connect.NewRequest(&apiv1.TenantServiceGetRequest{
+		Login: tenant,
+	}))
+	if err != nil {
+		return nil, err
+	}
+	for _, member := range tenantResp.Msg.TenantMembers {
+		if member.Id == memberId {
+			return member, nil
+		}
+	}
+	return nil, nil
+}
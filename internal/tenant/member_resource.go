@@ -0,0 +1,206 @@
+package tenant
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	apiv1 "github.com/metal-stack-cloud/api/go/api/v1"
+	"github.com/metal-stack-cloud/terraform-provider-metal/internal/session"
+	"github.com/metal-stack-cloud/terraform-provider-metal/internal/shared"
+)
+
+var (
+	_ resource.Resource                = &TenantMemberResource{}
+	_ resource.ResourceWithConfigure   = &TenantMemberResource{}
+	_ resource.ResourceWithImportState = &TenantMemberResource{}
+	_ resource.ResourceWithModifyPlan  = &TenantMemberResource{}
+)
+
+func NewTenantMemberResource() resource.Resource {
+	return &TenantMemberResource{}
+}
+
+type TenantMemberResource struct {
+	session *session.Session
+}
+
+// Metadata implements resource.Resource.
+func (*TenantMemberResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tenant_member"
+}
+
+// Schema implements resource.Resource.
+func (*TenantMemberResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: tenantMemberResourceAttributes(),
+		MarkdownDescription: "Binds a role to a member of a tenant (organization). \n" +
+			"Users can only join a tenant by accepting an invite, so the user must be a member already. " +
+			"Destroying the resource removes the user from the tenant. \n" +
+			"Required permissions: `Tenant Get`, `Tenant UpdateMember`, `Tenant RemoveMember`. " +
+			"Can be imported by `tenant/member_id`, or by `member_id` for the tenant owning the provider project.",
+	}
+}
+
+// Configure implements resource.ResourceWithConfigure.
+func (m *TenantMemberResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	session, ok := req.ProviderData.(*session.Session)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *session.Session, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	m.session = session
+}
+
+// Create implements resource.Resource.
+func (m *TenantMemberResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan tenantMemberModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tenant := plan.Tenant.ValueString()
+	if tenant == "" {
+		providerTenant, err := m.session.ProviderTenant(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to determine the tenant of the provider project", err.Error())
+			return
+		}
+		tenant = providerTenant
+	}
+
+	member, err := getTenantMember(ctx, m.session, tenant, plan.MemberId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get tenant members", err.Error())
+		return
+	}
+	if member == nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("member_id"),
+			"User is no tenant member",
+			fmt.Sprintf("The user %q has not joined the tenant %q. Invite the user to the tenant first.", plan.MemberId.ValueString(), tenant),
+		)
+		return
+	}
+
+	if shared.TenantRoleToString(member.Role) != plan.Role.ValueString() {
+		member, err = m.updateRole(ctx, tenant, member.Id, plan.Role.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to update tenant member", err.Error())
+			return
+		}
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, tenantMemberFromApi(tenant, member))...)
+}
+
+// Read implements resource.Resource.
+func (m *TenantMemberResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state tenantMemberModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	member, err := getTenantMember(ctx, m.session, state.Tenant.ValueString(), state.MemberId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get tenant members", err.Error())
+		return
+	}
+	if member == nil {
+		// the user left or was removed outside of terraform
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, tenantMemberFromApi(state.Tenant.ValueString(), member))...)
+}
+
+// Update implements resource.Resource.
+func (m *TenantMemberResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state tenantMemberModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	var plan tenantMemberModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	member, err := m.updateRole(ctx, state.Tenant.ValueString(), state.MemberId.ValueString(), plan.Role.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to update tenant member", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, tenantMemberFromApi(state.Tenant.ValueString(), member))...)
+}
+
+// Delete implements resource.Resource.
+func (m *TenantMemberResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state tenantMemberModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := m.session.Client.Apiv1().Tenant().RemoveMember(ctx, connect.NewRequest(&apiv1.TenantServiceRemoveMemberRequest{
+		Login:    state.Tenant.ValueString(),
+		MemberId: state.MemberId.ValueString(),
+	}))
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to remove tenant member", err.Error())
+		return
+	}
+}
+
+// ModifyPlan implements resource.ResourceWithModifyPlan.
+func (m *TenantMemberResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	m.session.CheckTenantPlan(ctx, req, resp)
+}
+
+// ImportState implements resource.ResourceWithImportState.
+func (m *TenantMemberResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	tenant, memberId, found := strings.Cut(req.ID, "/")
+	if !found {
+		providerTenant, err := m.session.ProviderTenant(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to determine the tenant of the provider project", err.Error())
+			return
+		}
+		tenant, memberId = providerTenant, req.ID
+	}
+	if tenant == "" || memberId == "" {
+		resp.Diagnostics.AddError("Invalid import ID", fmt.Sprintf("expected tenant/member_id or member_id, got %q", req.ID))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), tenantMemberId(tenant, memberId))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tenant"), tenant)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("member_id"), memberId)...)
+}
+
+func (m *TenantMemberResource) updateRole(ctx context.Context, tenant, memberId, role string) (*apiv1.TenantMember, error) {
+	tenantRole, err := shared.TenantRoleFromString(role)
+	if err != nil {
+		return nil, err
+	}
+	updateResp, err := m.session.Client.Apiv1().Tenant().UpdateMember(ctx, connect.NewRequest(&apiv1.TenantServiceUpdateMemberRequest{
+		Login:    tenant,
+		MemberId: memberId,
+		Role:     tenantRole,
+	}))
+	if err != nil {
+		return nil, err
+	}
+	return updateResp.Msg.TenantMember, nil
+}
@@ -0,0 +1,68 @@
+package tenant
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	apiv1 "github.com/metal-stack-cloud/api/go/api/v1"
+	"github.com/metal-stack-cloud/terraform-provider-metal/internal/shared"
+)
+
+type tenantModel struct {
+	Login         types.String `tfsdk:"id"`
+	Name          types.String `tfsdk:"name"`
+	Email         types.String `tfsdk:"email"`
+	Description   types.String `tfsdk:"description"`
+	AvatarUrl     types.String `tfsdk:"avatar_url"`
+	OauthProvider types.String `tfsdk:"oauth_provider"`
+	Admitted      types.Bool   `tfsdk:"admitted"`
+	EmailConsent  types.Bool   `tfsdk:"email_consent"`
+	Onboarded     types.Bool   `tfsdk:"onboarded"`
+	CreatedAt     types.String `tfsdk:"created_at"`
+	UpdatedAt     types.String `tfsdk:"updated_at"`
+}
+
+func tenantFromApi(t *apiv1.Tenant) tenantModel {
+	return tenantModel{
+		Login:         types.StringValue(t.Login),
+		Name:          types.StringValue(t.Name),
+		Email:         types.StringValue(t.Email),
+		Description:   types.StringValue(t.Description),
+		AvatarUrl:     types.StringValue(t.AvatarUrl),
+		OauthProvider: types.StringValue(shared.OAuthProviderToString(t.OauthProvider)),
+		Admitted:      types.BoolValue(t.Admitted),
+		EmailConsent:  types.BoolValue(t.EmailConsent),
+		Onboarded:     types.BoolValue(t.Onboarded),
+		CreatedAt:     types.StringValue(t.CreatedAt.AsTime().String()),
+		UpdatedAt:     types.StringValue(t.UpdatedAt.AsTime().String()),
+	}
+}
+
+type tenantMemberModel struct {
+	Id        types.String   `tfsdk:"id"`
+	Tenant    types.String   `tfsdk:"tenant"`
+	MemberId  types.String   `tfsdk:"member_id"`
+	Role      types.String   `tfsdk:"role"`
+	Projects  []types.String `tfsdk:"projects"`
+	CreatedAt types.String   `tfsdk:"created_at"`
+}
+
+func tenantMemberFromApi(tenant string, m *apiv1.TenantMember) tenantMemberModel {
+	projects := make([]types.String, len(m.Projects))
+	for i, project := range m.Projects {
+		projects[i] = types.StringValue(project)
+	}
+	return tenantMemberModel{
+		Id:        types.StringValue(tenantMemberId(tenant, m.Id)),
+		Tenant:    types.StringValue(tenant),
+		MemberId:  types.StringValue(m.Id),
+		Role:      types.StringValue(shared.TenantRoleToString(m.Role)),
+		Projects:  projects,
+		CreatedAt: types.StringValue(m.CreatedAt.AsTime().String()),
+	}
+}
+
+// tenantMemberId is the terraform ID of a membership, which is also accepted on import.
+func tenantMemberId(tenant, memberId string) string {
+	return fmt.Sprintf("%s/%s", tenant, memberId)
+}
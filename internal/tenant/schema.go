@@ -0,0 +1,110 @@
+package tenant
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	dataschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/metal-stack-cloud/terraform-provider-metal/internal/shared"
+)
+
+func tenantDataSourceAttributes() map[string]dataschema.Attribute {
+	return map[string]dataschema.Attribute{
+		"id": dataschema.StringAttribute{
+			Optional:    true,
+			Computed:    true,
+			Description: "The login of the tenant (organization). Defaults to the tenant owning the provider project.",
+		},
+		"name": dataschema.StringAttribute{
+			Computed:    true,
+			Description: "The name of the tenant.",
+		},
+		"email": dataschema.StringAttribute{
+			Computed:    true,
+			Description: "The email address of the tenant.",
+		},
+		"description": dataschema.StringAttribute{
+			Computed:    true,
+			Description: "A description of the tenant.",
+		},
+		"avatar_url": dataschema.StringAttribute{
+			Computed:    true,
+			Description: "The URL of the avatar of the tenant.",
+		},
+		"oauth_provider": dataschema.StringAttribute{
+			Computed:    true,
+			Description: "The provider the tenant signed up with, either 'github', 'azure' or 'google'.",
+		},
+		"admitted": dataschema.BoolAttribute{
+			Computed:    true,
+			Description: "Indicates if the tenant has been admitted to use metalstack.cloud.",
+		},
+		"email_consent": dataschema.BoolAttribute{
+			Computed:    true,
+			Description: "Indicates if the tenant agreed to receive emails.",
+		},
+		"onboarded": dataschema.BoolAttribute{
+			Computed:    true,
+			Description: "Indicates if the tenant completed the onboarding.",
+		},
+		"created_at": dataschema.StringAttribute{
+			Computed:    true,
+			Description: "Indicates when this tenant has been created.",
+		},
+		"updated_at": dataschema.StringAttribute{
+			Computed:    true,
+			Description: "Indicates when this tenant has been updated.",
+		},
+	}
+}
+
+func tenantMemberResourceAttributes() map[string]resourceschema.Attribute {
+	return map[string]resourceschema.Attribute{
+		"id": resourceschema.StringAttribute{
+			Computed:    true,
+			Description: "The ID of the membership in the form `tenant/member_id`.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"tenant": resourceschema.StringAttribute{
+			Optional:    true,
+			Computed:    true,
+			Description: "The tenant (organization) of the membership. Defaults to the tenant owning the provider project.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"member_id": resourceschema.StringAttribute{
+			Required:    true,
+			Description: "The login of the user, e.g. `octocat@github`. The user must have joined the tenant already.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"role": resourceschema.StringAttribute{
+			Required: true,
+			Description: "The role of the member in the tenant. Must be one of 'owner', 'editor', 'viewer' or 'guest'. " +
+				"Guests can only access the projects they are a member of.",
+			Validators: []validator.String{
+				stringvalidator.OneOf(shared.TenantRoles...),
+			},
+		},
+		"projects": resourceschema.ListAttribute{
+			Computed:    true,
+			ElementType: types.StringType,
+			Description: "The projects of the tenant the user is a member of.",
+		},
+		"created_at": resourceschema.StringAttribute{
+			Computed:    true,
+			Description: "Indicates when the user joined the tenant.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+	}
+}
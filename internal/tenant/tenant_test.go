@@ -0,0 +1,68 @@
+package tenant
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	apiv1 "github.com/metal-stack-cloud/api/go/api/v1"
+	assert "github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func Test_tenantFromApi(t *testing.T) {
+	tenant := &apiv1.Tenant{
+		Login:         "acme@github",
+		Name:          "ACME",
+		Email:         "billing@acme.example",
+		Description:   "ACME Corporation",
+		AvatarUrl:     "https://avatars.example/acme",
+		OauthProvider: apiv1.OAuthProvider_O_AUTH_PROVIDER_GITHUB,
+		Admitted:      true,
+		EmailConsent:  false,
+		Onboarded:     true,
+		CreatedAt: &timestamppb.Timestamp{
+			Seconds: int64(1707382100),
+		},
+		UpdatedAt: &timestamppb.Timestamp{
+			Seconds: int64(1717932877),
+		},
+	}
+	want := tenantModel{
+		Login:         basetypes.NewStringValue("acme@github"),
+		Name:          basetypes.NewStringValue("ACME"),
+		Email:         basetypes.NewStringValue("billing@acme.example"),
+		Description:   basetypes.NewStringValue("ACME Corporation"),
+		AvatarUrl:     basetypes.NewStringValue("https://avatars.example/acme"),
+		OauthProvider: basetypes.NewStringValue("github"),
+		Admitted:      basetypes.NewBoolValue(true),
+		EmailConsent:  basetypes.NewBoolValue(false),
+		Onboarded:     basetypes.NewBoolValue(true),
+		CreatedAt:     basetypes.NewStringValue("2024-02-08 08:48:20 +0000 UTC"),
+		UpdatedAt:     basetypes.NewStringValue("2024-06-09 11:34:37 +0000 UTC"),
+	}
+
+	assert.Equal(t, want, tenantFromApi(tenant))
+}
+
+func Test_tenantMemberFromApi(t *testing.T) {
+	member := &apiv1.TenantMember{
+		Id:       "octocat@github",
+		Role:     apiv1.TenantRole_TENANT_ROLE_GUEST,
+		Projects: []string{"7c8f1d2e-3a4b-4c5d-8e6f-9a0b1c2d3e4f"},
+		CreatedAt: &timestamppb.Timestamp{
+			Seconds: int64(1707382100),
+		},
+	}
+	want := tenantMemberModel{
+		Id:       basetypes.NewStringValue("acme@github/octocat@github"),
+		Tenant:   basetypes.NewStringValue("acme@github"),
+		MemberId: basetypes.NewStringValue("octocat@github"),
+		Role:     basetypes.NewStringValue("guest"),
+		Projects: []basetypes.StringValue{
+			basetypes.NewStringValue("7c8f1d2e-3a4b-4c5d-8e6f-9a0b1c2d3e4f"),
+		},
+		CreatedAt: basetypes.NewStringValue("2024-02-08 08:48:20 +0000 UTC"),
+	}
+
+	assert.Equal(t, want, tenantMemberFromApi("acme@github", member))
+}
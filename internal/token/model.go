@@ -0,0 +1,123 @@
+package token
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	apiv1 "github.com/metal-stack-cloud/api/go/api/v1"
+	"github.com/metal-stack-cloud/terraform-provider-metal/internal/shared"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+type tokenModel struct {
+	Uuid             types.String            `tfsdk:"id"`
+	Description      types.String            `tfsdk:"description"`
+	ExpiresIn        types.String            `tfsdk:"expires_in"`
+	ProjectRoles     map[string]types.String `tfsdk:"project_roles"`
+	TenantRoles      map[string]types.String `tfsdk:"tenant_roles"`
+	Permissions      []tokenPermissionModel  `tfsdk:"permissions"`
+	RotationTriggers map[string]types.String `tfsdk:"rotation_triggers"`
+	Secret           types.String            `tfsdk:"secret"`
+	UserId           types.String            `tfsdk:"user_id"`
+	ExpiresAt        types.String            `tfsdk:"expires_at"`
+	IssuedAt         types.String            `tfsdk:"issued_at"`
+}
+
+type tokenPermissionModel struct {
+	Subject types.String   `tfsdk:"subject"`
+	Methods []types.String `tfsdk:"methods"`
+}
+
+// tokenCreateRequest converts the planned token, roles are validated by the schema already.
+func tokenCreateRequest(plan tokenModel) (*apiv1.TokenServiceCreateRequest, error) {
+	expires, err := time.ParseDuration(plan.ExpiresIn.ValueString())
+	if err != nil {
+		return nil, fmt.Errorf("expires_in is not a valid duration: %w", err)
+	}
+	req := &apiv1.TokenServiceCreateRequest{
+		Description: plan.Description.ValueString(),
+		Expires:     durationpb.New(expires),
+	}
+	if len(plan.ProjectRoles) > 0 {
+		req.ProjectRoles = make(map[string]apiv1.ProjectRole, len(plan.ProjectRoles))
+		for project, role := range plan.ProjectRoles {
+			projectRole, err := shared.ProjectRoleFromString(role.ValueString())
+			if err != nil {
+				return nil, err
+			}
+			req.ProjectRoles[project] = projectRole
+		}
+	}
+	if len(plan.TenantRoles) > 0 {
+		req.TenantRoles = make(map[string]apiv1.TenantRole, len(plan.TenantRoles))
+		for tenant, role := range plan.TenantRoles {
+			tenantRole, err := shared.TenantRoleFromString(role.ValueString())
+			if err != nil {
+				return nil, err
+			}
+			req.TenantRoles[tenant] = tenantRole
+		}
+	}
+	for _, permission := range plan.Permissions {
+		methods := make([]string, len(permission.Methods))
+		for i, method := range permission.Methods {
+			methods[i] = method.ValueString()
+		}
+		req.Permissions = append(req.Permissions, &apiv1.MethodPermission{
+			Subject: permission.Subject.ValueString(),
+			Methods: methods,
+		})
+	}
+	return req, nil
+}
+
+// withTokenFromApi sets the computed attributes, tokens cannot be changed so the configured ones are kept.
+func withTokenFromApi(model tokenModel, t *apiv1.Token) tokenModel {
+	model.Uuid = types.StringValue(t.Uuid)
+	model.UserId = types.StringValue(t.UserId)
+	model.ExpiresAt = types.StringValue(t.Expires.AsTime().String())
+	model.IssuedAt = types.StringValue(t.IssuedAt.AsTime().String())
+	return model
+}
+
+type tokenScopeModel struct {
+	ContentId    types.String              `tfsdk:"id"`
+	ProjectRoles map[string]types.String   `tfsdk:"project_roles"`
+	TenantRoles  map[string]types.String   `tfsdk:"tenant_roles"`
+	Permissions  map[string][]types.String `tfsdk:"permissions"`
+}
+
+func tokenScopeFromApi(scope *apiv1.MethodServiceTokenScopedListResponse) tokenScopeModel {
+	model := tokenScopeModel{
+		ProjectRoles: make(map[string]types.String, len(scope.GetProjectRoles())),
+		TenantRoles:  make(map[string]types.String, len(scope.GetTenantRoles())),
+		Permissions:  map[string][]types.String{},
+	}
+	for project, role := range scope.GetProjectRoles() {
+		model.ProjectRoles[project] = types.StringValue(shared.ProjectRoleToString(role))
+	}
+	for tenant, role := range scope.GetTenantRoles() {
+		model.TenantRoles[tenant] = types.StringValue(shared.TenantRoleToString(role))
+	}
+
+	// the api may return several permissions for the same subject
+	methodsBySubject := map[string][]string{}
+	for _, permission := range scope.GetPermissions() {
+		methodsBySubject[permission.GetSubject()] = append(methodsBySubject[permission.GetSubject()], permission.GetMethods()...)
+	}
+	for subject, methods := range methodsBySubject {
+		slices.Sort(methods)
+		methods = slices.Compact(methods)
+		model.Permissions[subject] = make([]types.String, len(methods))
+		for i, method := range methods {
+			model.Permissions[subject][i] = types.StringValue(method)
+		}
+	}
+
+	content := fmt.Sprintf("%v%v%v", model.ProjectRoles, model.TenantRoles, model.Permissions)
+	model.ContentId = types.StringValue(fmt.Sprintf("%x", sha1.Sum([]byte(content))))
+	return model
+}
@@ -0,0 +1,153 @@
+package token
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	apiv1 "github.com/metal-stack-cloud/api/go/api/v1"
+	"github.com/metal-stack-cloud/terraform-provider-metal/internal/session"
+)
+
+var (
+	_ resource.Resource               = &TokenResource{}
+	_ resource.ResourceWithConfigure  = &TokenResource{}
+	_ resource.ResourceWithModifyPlan = &TokenResource{}
+)
+
+func NewTokenResource() resource.Resource {
+	return &TokenResource{}
+}
+
+type TokenResource struct {
+	session *session.Session
+}
+
+// Metadata implements resource.Resource.
+func (*TokenResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_token"
+}
+
+// Schema implements resource.Resource.
+func (*TokenResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: tokenResourceAttributes(),
+		MarkdownDescription: "Issues an API token, e.g. for a CI system. \n" +
+			"A token cannot be changed, every change issues a new one and revokes the old one. " +
+			"The secret is only known at creation and kept in the state, treat the state as sensitive. \n" +
+			"Required permissions: `Token Create`, `Token List`, `Token Revoke`. A token can only grant what the issuing token is allowed to do.",
+	}
+}
+
+// Configure implements resource.ResourceWithConfigure.
+func (t *TokenResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	session, ok := req.ProviderData.(*session.Session)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *session.Session, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	t.session = session
+}
+
+// Create implements resource.Resource.
+func (t *TokenResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan tokenModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createReq, err := tokenCreateRequest(plan)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid token", err.Error())
+		return
+	}
+	createResp, err := t.session.Client.Apiv1().Token().Create(ctx, connect.NewRequest(createReq))
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create token", err.Error())
+		return
+	}
+
+	state := withTokenFromApi(plan, createResp.Msg.Token)
+	state.Secret = types.StringValue(createResp.Msg.Secret)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Read implements resource.Resource.
+func (t *TokenResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state tokenModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	token, err := t.findToken(ctx, state.Uuid.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to list tokens", err.Error())
+		return
+	}
+	if token == nil {
+		// the token expired or was revoked outside of terraform
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state = withTokenFromApi(state, token)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update implements resource.Resource.
+func (t *TokenResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// all configurable attributes require a replacement
+	var plan tokenModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete implements resource.Resource.
+func (t *TokenResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state tokenModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := t.session.Client.Apiv1().Token().Revoke(ctx, connect.NewRequest(&apiv1.TokenServiceRevokeRequest{
+		Uuid: state.Uuid.ValueString(),
+	}))
+	if err != nil && connect.CodeOf(err) != connect.CodeNotFound {
+		resp.Diagnostics.AddError("Failed to revoke token", err.Error())
+		return
+	}
+}
+
+// ModifyPlan implements resource.ResourceWithModifyPlan.
+func (t *TokenResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	t.session.CheckPlan(ctx, req, resp, session.ProjectKeys(path.Root("project_roles")))
+}
+
+// findToken returns the token with the given ID, or nil if it is not valid anymore.
+func (t *TokenResource) findToken(ctx context.Context, uuid string) (*apiv1.Token, error) {
+	listResp, err := t.session.Client.Apiv1().Token().List(ctx, connect.NewRequest(&apiv1.TokenServiceListRequest{}))
+	if err != nil {
+		return nil, err
+	}
+	for _, token := range listResp.Msg.Tokens {
+		if token.Uuid == uuid {
+			return token, nil
+		}
+	}
+	return nil, nil
+}
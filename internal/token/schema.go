@@ -0,0 +1,124 @@
+package token
+
+import (
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/metal-stack-cloud/terraform-provider-metal/internal/shared"
+)
+
+func tokenResourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed:    true,
+			Description: "The ID of the token.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"description": schema.StringAttribute{
+			Required:    true,
+			Description: "Describes what the token is used for.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"expires_in": schema.StringAttribute{
+			Required:    true,
+			Description: "How long the token is valid after creation, as duration like `720h`. Expired tokens are removed from the state and issued again on the next apply.",
+			Validators: []validator.String{
+				stringvalidator.RegexMatches(regexp.MustCompile(`^([0-9]+(h|m|s))+$`), "must be a duration like 720h or 90m"),
+			},
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"project_roles": schema.MapAttribute{
+			Optional:    true,
+			ElementType: types.StringType,
+			Description: "The roles of the token by project ID. Must be one of 'owner', 'editor' or 'viewer'.",
+			Validators: []validator.Map{
+				mapvalidator.ValueStringsAre(stringvalidator.OneOf(shared.ProjectRoles...)),
+			},
+			PlanModifiers: []planmodifier.Map{
+				mapplanmodifier.RequiresReplace(),
+			},
+		},
+		"tenant_roles": schema.MapAttribute{
+			Optional:    true,
+			ElementType: types.StringType,
+			Description: "The roles of the token by tenant login. Must be one of 'owner', 'editor', 'viewer' or 'guest'.",
+			Validators: []validator.Map{
+				mapvalidator.ValueStringsAre(stringvalidator.OneOf(shared.TenantRoles...)),
+			},
+			PlanModifiers: []planmodifier.Map{
+				mapplanmodifier.RequiresReplace(),
+			},
+		},
+		"permissions": schema.ListNestedAttribute{
+			Optional:    true,
+			Description: "Explicit permissions of the token, in addition to its roles.",
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"subject": schema.StringAttribute{
+						Required:    true,
+						Description: "The project ID or tenant login the methods may be called on.",
+					},
+					"methods": schema.ListAttribute{
+						Required:    true,
+						ElementType: types.StringType,
+						Description: "The allowed methods, e.g. `/api.v1.ClusterService/Get`. The `metal_token_scope` data source shows the methods of the provider token.",
+					},
+				},
+			},
+			PlanModifiers: []planmodifier.List{
+				listplanmodifier.RequiresReplace(),
+			},
+		},
+		"rotation_triggers": schema.MapAttribute{
+			Optional:    true,
+			ElementType: types.StringType,
+			Description: "Arbitrary values which issue a new token when changed, e.g. a timestamp of a `time_rotating` resource.",
+			PlanModifiers: []planmodifier.Map{
+				mapplanmodifier.RequiresReplace(),
+			},
+		},
+		"secret": schema.StringAttribute{
+			Computed:    true,
+			Sensitive:   true,
+			Description: "The secret to pass as api_token. It is only returned when the token is issued.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"user_id": schema.StringAttribute{
+			Computed:    true,
+			Description: "The user who issued the token.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"expires_at": schema.StringAttribute{
+			Computed:    true,
+			Description: "Indicates when the token expires.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"issued_at": schema.StringAttribute{
+			Computed:    true,
+			Description: "Indicates when the token was issued.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+	}
+}
@@ -0,0 +1,90 @@
+package token
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	apiv1 "github.com/metal-stack-cloud/api/go/api/v1"
+	"github.com/metal-stack-cloud/terraform-provider-metal/internal/session"
+)
+
+var (
+	_ datasource.DataSource              = &TokenScopeDataSource{}
+	_ datasource.DataSourceWithConfigure = &TokenScopeDataSource{}
+)
+
+func NewTokenScopeDataSource() datasource.DataSource {
+	return &TokenScopeDataSource{}
+}
+
+// TokenScopeDataSource defines the data source implementation.
+type TokenScopeDataSource struct {
+	session *session.Session
+}
+
+// Metadata implements datasource.DataSource.
+func (*TokenScopeDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_token_scope"
+}
+
+// Schema implements datasource.DataSource.
+func (*TokenScopeDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Shows what the api token of the provider is allowed to do. \n" +
+			"Useful to assert in `precondition` blocks that the token has the roles or permissions a module needs.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"project_roles": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The roles of the token by project ID, one of 'owner', 'editor' or 'viewer'.",
+			},
+			"tenant_roles": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The roles of the token by tenant login, one of 'owner', 'editor', 'viewer' or 'guest'.",
+			},
+			"permissions": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.ListType{ElemType: types.StringType},
+				Description: "The methods the token may call explicitly by subject, i.e. by project ID or tenant login.",
+			},
+		},
+	}
+}
+
+// Configure implements datasource.DataSourceWithConfigure.
+func (t *TokenScopeDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	session, ok := req.ProviderData.(*session.Session)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *session.Session, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	t.session = session
+}
+
+// Read implements datasource.DataSource.
+func (t *TokenScopeDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	scopeResp, err := t.session.Client.Apiv1().Method().TokenScopedList(ctx, connect.NewRequest(&apiv1.MethodServiceTokenScopedListRequest{}))
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read the token scope", err.Error())
+		return
+	}
+
+	data := tokenScopeFromApi(scopeResp.Msg)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,75 @@
+package token_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/metal-stack-cloud/terraform-provider-metal/internal/provider"
+)
+
+var (
+	testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+		"metal": providerserver.NewProtocol6WithError(provider.New("test")()),
+	}
+)
+
+func TestAccTokenResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTokenResourceConfig("1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("metal_token.test", "id"),
+					resource.TestCheckResourceAttrSet("metal_token.test", "secret"),
+					resource.TestCheckResourceAttrSet("metal_token.test", "expires_at"),
+				),
+			},
+			{
+				Config: testAccTokenResourceConfig("2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("metal_token.test", "rotation_triggers.generation", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccTokenResourceConfig(generation string) string {
+	return `
+data "metal_tenant" "current" {}
+
+resource "metal_token" "test" {
+  description = "tf-acc-test"
+  expires_in  = "1h"
+
+  tenant_roles = {
+    (data.metal_tenant.current.id) = "viewer"
+  }
+
+  rotation_triggers = {
+    generation = "` + generation + `"
+  }
+}
+`
+}
+
+func TestAccTokenScopeDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTokenScopeDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.metal_token_scope.current", "id"),
+				),
+			},
+		},
+	})
+}
+
+const testAccTokenScopeDataSourceConfig = `
+data "metal_token_scope" "current" {}
+`
@@ -0,0 +1,123 @@
+package token
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	apiv1 "github.com/metal-stack-cloud/api/go/api/v1"
+	assert "github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func Test_tokenCreateRequest(t *testing.T) {
+	plan := tokenModel{
+		Description: basetypes.NewStringValue("ci of cluster a"),
+		ExpiresIn:   basetypes.NewStringValue("720h"),
+		ProjectRoles: map[string]basetypes.StringValue{
+			"7c8f1d2e-3a4b-4c5d-8e6f-9a0b1c2d3e4f": basetypes.NewStringValue("editor"),
+		},
+		TenantRoles: map[string]basetypes.StringValue{
+			"acme@github": basetypes.NewStringValue("guest"),
+		},
+		Permissions: []tokenPermissionModel{
+			{
+				Subject: basetypes.NewStringValue("7c8f1d2e-3a4b-4c5d-8e6f-9a0b1c2d3e4f"),
+				Methods: []basetypes.StringValue{
+					basetypes.NewStringValue("/api.v1.ClusterService/Get"),
+				},
+			},
+		},
+	}
+	want := &apiv1.TokenServiceCreateRequest{
+		Description: "ci of cluster a",
+		Expires:     durationpb.New(720 * time.Hour),
+		ProjectRoles: map[string]apiv1.ProjectRole{
+			"7c8f1d2e-3a4b-4c5d-8e6f-9a0b1c2d3e4f": apiv1.ProjectRole_PROJECT_ROLE_EDITOR,
+		},
+		TenantRoles: map[string]apiv1.TenantRole{
+			"acme@github": apiv1.TenantRole_TENANT_ROLE_GUEST,
+		},
+		Permissions: []*apiv1.MethodPermission{
+			{
+				Subject: "7c8f1d2e-3a4b-4c5d-8e6f-9a0b1c2d3e4f",
+				Methods: []string{"/api.v1.ClusterService/Get"},
+			},
+		},
+	}
+
+	got, err := tokenCreateRequest(plan)
+	assert.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	plan.ExpiresIn = basetypes.NewStringValue("1 month")
+	_, err = tokenCreateRequest(plan)
+	assert.ErrorContains(t, err, "expires_in is not a valid duration")
+}
+
+func Test_withTokenFromApi(t *testing.T) {
+	plan := tokenModel{
+		Description: basetypes.NewStringValue("ci of cluster a"),
+		ExpiresIn:   basetypes.NewStringValue("720h"),
+		Secret:      basetypes.NewStringValue("secret"),
+	}
+	token := &apiv1.Token{
+		Uuid:        "a1b2c3d4-e5f6-4a7b-8c9d-0e1f2a3b4c5d",
+		UserId:      "octocat@github",
+		Description: "ci of cluster a",
+		Expires: &timestamppb.Timestamp{
+			Seconds: int64(1717932877),
+		},
+		IssuedAt: &timestamppb.Timestamp{
+			Seconds: int64(1707382100),
+		},
+	}
+	want := tokenModel{
+		Uuid:        basetypes.NewStringValue("a1b2c3d4-e5f6-4a7b-8c9d-0e1f2a3b4c5d"),
+		Description: basetypes.NewStringValue("ci of cluster a"),
+		ExpiresIn:   basetypes.NewStringValue("720h"),
+		Secret:      basetypes.NewStringValue("secret"),
+		UserId:      basetypes.NewStringValue("octocat@github"),
+		ExpiresAt:   basetypes.NewStringValue("2024-06-09 11:34:37 +0000 UTC"),
+		IssuedAt:    basetypes.NewStringValue("2024-02-08 08:48:20 +0000 UTC"),
+	}
+
+	assert.Equal(t, want, withTokenFromApi(plan, token))
+}
+
+func Test_tokenScopeFromApi(t *testing.T) {
+	scope := &apiv1.MethodServiceTokenScopedListResponse{
+		ProjectRoles: map[string]apiv1.ProjectRole{
+			"7c8f1d2e-3a4b-4c5d-8e6f-9a0b1c2d3e4f": apiv1.ProjectRole_PROJECT_ROLE_OWNER,
+		},
+		TenantRoles: map[string]apiv1.TenantRole{
+			"acme@github": apiv1.TenantRole_TENANT_ROLE_VIEWER,
+		},
+		Permissions: []*apiv1.MethodPermission{
+			{
+				Subject: "7c8f1d2e-3a4b-4c5d-8e6f-9a0b1c2d3e4f",
+				Methods: []string{"/api.v1.IPService/List", "/api.v1.ClusterService/Get"},
+			},
+			{
+				Subject: "7c8f1d2e-3a4b-4c5d-8e6f-9a0b1c2d3e4f",
+				Methods: []string{"/api.v1.ClusterService/Get"},
+			},
+		},
+	}
+
+	got := tokenScopeFromApi(scope)
+	assert.Equal(t, map[string]basetypes.StringValue{
+		"7c8f1d2e-3a4b-4c5d-8e6f-9a0b1c2d3e4f": basetypes.NewStringValue("owner"),
+	}, got.ProjectRoles)
+	assert.Equal(t, map[string]basetypes.StringValue{
+		"acme@github": basetypes.NewStringValue("viewer"),
+	}, got.TenantRoles)
+	assert.Equal(t, map[string][]basetypes.StringValue{
+		"7c8f1d2e-3a4b-4c5d-8e6f-9a0b1c2d3e4f": {
+			basetypes.NewStringValue("/api.v1.ClusterService/Get"),
+			basetypes.NewStringValue("/api.v1.IPService/List"),
+		},
+	}, got.Permissions)
+	assert.Equal(t, got.ContentId, tokenScopeFromApi(scope).ContentId)
+}
@@ -8,6 +8,7 @@ import (
 	datasource "github.com/hashicorp/terraform-plugin-framework/datasource"
 	schema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	apiv1 "github.com/metal-stack-cloud/api/go/api/v1"
+	"github.com/metal-stack-cloud/api/go/api/v1/apiv1connect"
 	session "github.com/metal-stack-cloud/terraform-provider-metal/internal/session"
 )
 
@@ -74,17 +75,21 @@ func (v *VolumeDataSource) Read(ctx context.Context, request datasource.ReadRequ
 	// get all volumes and select volume by name if uuid is not set
 	var uuidString string
 	if data.Uuid.ValueString() == "" {
-		listRequestMessage := &apiv1.VolumeServiceListRequest{
-			Project: project,
-		}
 		// get volumeList type volumes []*volume
-		volumeList, err := v.session.Client.Apiv1().Volume().List(ctx, connect.NewRequest(listRequestMessage))
+		list, err := session.CachedList(ctx, v.session.Cache, apiv1connect.VolumeServiceName, project, func(ctx context.Context) ([]*apiv1.Volume, error) {
+			volumeList, err := v.session.Client.Apiv1().Volume().List(ctx, connect.NewRequest(&apiv1.VolumeServiceListRequest{
+				Project: project,
+			}))
+			if err != nil {
+				return nil, err
+			}
+			return volumeList.Msg.Volumes, nil
+		})
 		if err != nil {
 			response.Diagnostics.AddError("Failed to get volume list", err.Error())
 			return
 		}
 		// find uuid and set uuidString
-		list := volumeList.Msg.Volumes
 		returnString, err := findUuid(list, data.Name.ValueString())
 		if err != nil {
 			response.Diagnostics.AddError(fmt.Sprintf("Failed to find volume with name %v", data.Name.ValueString()), err.Error())